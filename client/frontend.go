@@ -179,6 +179,7 @@ func executeNVMeNamespace(ctx context.Context, conn grpc.ClientConnInterface) er
 			Spec: &pb.NVMeControllerSpec{
 				Id:               &pbc.ObjectKey{Value: "namespace-test-ctrler"},
 				SubsystemId:      &pbc.ObjectKey{Value: "namespace-test-ss"},
+				PcieId:           &pb.PciEndpoint{PortId: 0, PhysicalFunction: 1, VirtualFunction: 0},
 				NvmeControllerId: 1}}})
 	if err != nil {
 		log.Fatalf("could not create NVMe controller: %v", err)
@@ -273,6 +274,7 @@ func executeNVMeController(ctx context.Context, conn grpc.ClientConnInterface) e
 			Spec: &pb.NVMeControllerSpec{
 				Id:               &pbc.ObjectKey{Value: "controller-test"},
 				SubsystemId:      &pbc.ObjectKey{Value: "controller-test-ss"},
+				PcieId:           &pb.PciEndpoint{PortId: 0, PhysicalFunction: 2, VirtualFunction: 0},
 				NvmeControllerId: 1}}})
 	if err != nil {
 		log.Fatalf("could not create NVMe controller: %v", err)
@@ -284,6 +286,7 @@ func executeNVMeController(ctx context.Context, conn grpc.ClientConnInterface) e
 			Spec: &pb.NVMeControllerSpec{
 				Id:               &pbc.ObjectKey{Value: "controller-test"},
 				SubsystemId:      &pbc.ObjectKey{Value: "controller-test-ss"},
+				PcieId:           &pb.PciEndpoint{PortId: 0, PhysicalFunction: 2, VirtualFunction: 0},
 				NvmeControllerId: 2}}})
 	if err != nil {
 		log.Fatalf("could not update NVMe controller: %v", err)
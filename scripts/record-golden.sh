@@ -0,0 +1,46 @@
+#!/bin/bash
+# SPDX-License-Identifier: Apache-2.0
+# Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+# Records the golden files of server/testdata/spdk-v22.09 from a real SPDK
+# v22.09 target, by running the replay tests against it, then replays them.
+
+set -euxo pipefail
+
+SCRIPT_DIR=$( cd -- "$( dirname -- "${BASH_SOURCE[0]}" )" &> /dev/null && pwd )
+TAG=v22.09
+IMAGE=opi-spdk:${TAG}
+NAME=opi-spdk-golden
+SOCK=/var/tmp/spdk-golden.sock
+
+docker build --build-arg TAG="${TAG}" -t "${IMAGE}" "${SCRIPT_DIR}/../spdk"
+docker run -d --rm --name "${NAME}" --privileged --network host \
+    -v /dev/hugepages:/dev/hugepages -v /dev/shm:/dev/shm -v /var/tmp:/var/tmp \
+    "${IMAGE}" /usr/local/bin/spdk_tgt -m 0x1 -s 512 --no-pci -S /var/tmp -r "${SOCK}"
+trap 'docker stop "${NAME}"' EXIT
+
+rpc=(docker exec "${NAME}" /usr/libexec/spdk/scripts/rpc.py -s "${SOCK}")
+for i in $(seq 1 10)
+do
+    echo "$i"
+    if "${rpc[@]}" spdk_get_version
+    then
+        break
+    else
+        sleep 1
+    fi
+done
+
+# the volumes the tests use, and the remote target TestReplay_NVMfRemoteController connects to
+"${rpc[@]}" bdev_malloc_create -b Malloc0 64 512
+"${rpc[@]}" bdev_malloc_create -b Malloc1 64 512
+"${rpc[@]}" bdev_malloc_create -b Malloc2 64 512
+"${rpc[@]}" nvmf_create_transport -t TCP
+"${rpc[@]}" nvmf_create_subsystem nqn.2016-06.io.spdk:cnode1 -a -s SPDK00000000000001 -d SPDK_Controller1
+"${rpc[@]}" nvmf_subsystem_add_ns nqn.2016-06.io.spdk:cnode1 Malloc2
+"${rpc[@]}" nvmf_subsystem_add_listener nqn.2016-06.io.spdk:cnode1 -t tcp -a 127.0.0.1 -f ipv4 -s 4444
+
+cd "${SCRIPT_DIR}/../server"
+TESTS='TestReplay_(NVMeSubsystem|NullDebug|VirtioBlk|NVMfRemoteController)$'
+go test -count=1 -run "${TESTS}" . -args -golden_spdk="${SOCK}"
+go test -count=1 -run "${TESTS}" .
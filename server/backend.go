@@ -29,12 +29,22 @@ func (s *server) NVMfRemoteControllerConnect(ctx context.Context, in *pb.NVMfRem
 		Port:      fmt.Sprint(in.GetCtrl().GetTrsvcid()),
 		Subsystem: in.GetCtrl().GetSubnqn(),
 	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
 	var result []BdevNvmeAttachControllerResult
-	err := call("bdev_nvme_attach_controller", &params, &result)
+	existing, err := s.registry.remoteControllers.create(ctx, fmt.Sprint(in.GetCtrl().GetId()), in.GetCtrl(), recreating(ctx), func() error {
+		return tx.call("bdev_nvme_attach_controller", &params, &result,
+			undoCall("bdev_nvme_detach_controller", &BdevNvmeDetachControllerParams{Name: params.Name}))
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("NVMfRemoteControllerConnect: %d already exists", in.GetCtrl().GetId())
+		return &pb.NVMfRemoteControllerConnectResponse{}, nil
+	}
 	log.Printf("Received from SPDK: %v", result)
 	if len(result) != 1 {
 		log.Printf("expecting exactly 1 result")
@@ -48,7 +58,9 @@ func (s *server) NVMfRemoteControllerDisconnect(ctx context.Context, in *pb.NVMf
 		Name: fmt.Sprint("OpiNvme", in.GetId()),
 	}
 	var result BdevNvmeDetachControllerResult
-	err := call("bdev_nvme_detach_controller", &params, &result)
+	err := s.registry.remoteControllers.remove(ctx, fmt.Sprint(in.GetId()), func() error {
+		return call(ctx, "bdev_nvme_detach_controller", &params, &result)
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -65,7 +77,7 @@ func (s *server) NVMfRemoteControllerReset(ctx context.Context, in *pb.NVMfRemot
 func (s *server) NVMfRemoteControllerList(ctx context.Context, in *pb.NVMfRemoteControllerListRequest) (*pb.NVMfRemoteControllerListResponse, error) {
 	log.Printf("NVMfRemoteControllerList: Received from client: %v", in)
 	var result []BdevNvmeGetControllerResult
-	err := call("bdev_nvme_get_controllers", nil, &result)
+	err := call(ctx, "bdev_nvme_get_controllers", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -85,7 +97,7 @@ func (s *server) NVMfRemoteControllerGet(ctx context.Context, in *pb.NVMfRemoteC
 		Name: fmt.Sprint("OpiNvme", in.GetId()),
 	}
 	var result []BdevNvmeGetControllerResult
-	err := call("bdev_nvme_get_controllers", &params, &result)
+	err := call(ctx, "bdev_nvme_get_controllers", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -113,12 +125,22 @@ func (s *server) NullDebugCreate(ctx context.Context, in *pb.NullDebugCreateRequ
 		BlockSize: 512,
 		NumBlocks: 64,
 	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
 	var result BdevNullCreateResult
-	err := call("bdev_null_create", &params, &result)
+	existing, err := s.registry.nullDebugs.create(ctx, in.Device.Handle.Value, in.Device, recreating(ctx), func() error {
+		return tx.call("bdev_null_create", &params, &result,
+			undoCall("bdev_null_delete", &BdevNullDeleteParams{Name: params.Name}))
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("NullDebugCreate: %s already exists", in.Device.Handle.Value)
+		return existing.(*pb.NullDebug), nil
+	}
 	log.Printf("Received from SPDK: %v", result)
 	response := &pb.NullDebug{}
 	err = deepcopier.Copy(in.Device).To(response)
@@ -135,7 +157,9 @@ func (s *server) NullDebugDelete(ctx context.Context, in *pb.NullDebugDeleteRequ
 		Name: in.Handle.Value,
 	}
 	var result BdevNullDeleteResult
-	err := call("bdev_null_delete", &params, &result)
+	err := s.registry.nullDebugs.remove(ctx, in.Handle.Value, func() error {
+		return call(ctx, "bdev_null_delete", &params, &result)
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -149,36 +173,46 @@ func (s *server) NullDebugDelete(ctx context.Context, in *pb.NullDebugDeleteRequ
 
 func (s *server) NullDebugUpdate(ctx context.Context, in *pb.NullDebugUpdateRequest) (*pb.NullDebug, error) {
 	log.Printf("NullDebugUpdate: Received from client: %v", in)
-	params1 := BdevNullDeleteParams{
-		Name: in.Device.Handle.Value,
-	}
-	var result1 BdevNullDeleteResult
-	err1 := call("bdev_null_delete", &params1, &result1)
-	if err1 != nil {
-		log.Printf("error: %v", err1)
-		return nil, err1
-	}
-	log.Printf("Received from SPDK: %v", result1)
-	if !result1 {
-		log.Printf("Could not delete: %v", in)
-	}
-	params2 := BdevNullCreateParams{
-		Name:      in.Device.Handle.Value,
-		BlockSize: 512,
-		NumBlocks: 64,
-	}
-	var result2 BdevNullCreateResult
-	err2 := call("bdev_null_create", &params2, &result2)
-	if err2 != nil {
-		log.Printf("error: %v", err2)
-		return nil, err2
+	tx := newSaga(ctx)
+	defer tx.rollback()
+	err := s.registry.nullDebugs.put(ctx, in.Device.Handle.Value, in.Device, func() error {
+		params1 := BdevNullDeleteParams{
+			Name: in.Device.Handle.Value,
+		}
+		var result1 BdevNullDeleteResult
+		// null bdevs are always created the same way
+		restore := undoCall("bdev_null_create", &BdevNullCreateParams{Name: params1.Name, BlockSize: 512, NumBlocks: 64})
+		err1 := tx.call("bdev_null_delete", &params1, &result1, restore)
+		if err1 != nil {
+			return err1
+		}
+		log.Printf("Received from SPDK: %v", result1)
+		if !result1 {
+			log.Printf("Could not delete: %v", in)
+		}
+		params2 := BdevNullCreateParams{
+			Name:      in.Device.Handle.Value,
+			BlockSize: 512,
+			NumBlocks: 64,
+		}
+		var result2 BdevNullCreateResult
+		err2 := tx.call("bdev_null_create", &params2, &result2, undoCall("bdev_null_delete", &params1))
+		if err2 != nil {
+			return err2
+		}
+		log.Printf("Received from SPDK: %v", result2)
+		return nil
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result2)
+	tx.commit()
 	response := &pb.NullDebug{}
-	err3 := deepcopier.Copy(in.Device).To(response)
-	if err3 != nil {
-		log.Printf("error: %v", err3)
-		return nil, err3
+	err = deepcopier.Copy(in.Device).To(response)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
 	return response, nil
 }
@@ -186,7 +220,7 @@ func (s *server) NullDebugUpdate(ctx context.Context, in *pb.NullDebugUpdateRequ
 func (s *server) NullDebugList(ctx context.Context, in *pb.NullDebugListRequest) (*pb.NullDebugListResponse, error) {
 	log.Printf("NullDebugList: Received from client: %v", in)
 	var result []BdevGetBdevsResult
-	err := call("bdev_get_bdevs", nil, &result)
+	err := call(ctx, "bdev_get_bdevs", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -206,7 +240,7 @@ func (s *server) NullDebugGet(ctx context.Context, in *pb.NullDebugGetRequest) (
 		Name: in.Handle.Value,
 	}
 	var result []BdevGetBdevsResult
-	err := call("bdev_get_bdevs", &params, &result)
+	err := call(ctx, "bdev_get_bdevs", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -222,23 +256,17 @@ func (s *server) NullDebugGet(ctx context.Context, in *pb.NullDebugGetRequest) (
 
 func (s *server) NullDebugStats(ctx context.Context, in *pb.NullDebugStatsRequest) (*pb.NullDebugStatsResponse, error) {
 	log.Printf("NullDebugStats: Received from client: %v", in)
-	params := BdevGetIostatParams{
-		Name: in.Handle.Value,
-	}
-	// See https://mholt.github.io/json-to-go/
-	var result BdevGetIostatResult
-	err := call("bdev_get_iostat", &params, &result)
+	stats, err := getVolumeStats(ctx, in.Handle.Value, nil)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	if len(result.Bdevs) != 1 {
-		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	encoded, err := encodeVolumeStats(stats)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
-	return &pb.NullDebugStatsResponse{Stats: fmt.Sprint(result.Bdevs[0])}, nil
+	return &pb.NullDebugStatsResponse{Handle: in.Handle, Stats: encoded}, nil
 }
 
 //////////////////////////////////////////////////////////
@@ -250,12 +278,22 @@ func (s *server) AioControllerCreate(ctx context.Context, in *pb.AioControllerCr
 		BlockSize: 512,
 		Filename:  in.GetDevice().GetFilename(),
 	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
 	var result BdevAioCreateResult
-	err := call("bdev_aio_create", &params, &result)
+	existing, err := s.registry.aioControllers.create(ctx, in.GetDevice().GetHandle().GetValue(), in.GetDevice(), recreating(ctx), func() error {
+		return tx.call("bdev_aio_create", &params, &result,
+			undoCall("bdev_aio_delete", &BdevAioDeleteParams{Name: params.Name}))
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("AioControllerCreate: %s already exists", in.GetDevice().GetHandle().GetValue())
+		return &pb.AioController{}, nil
+	}
 	log.Printf("Received from SPDK: %v", result)
 	return &pb.AioController{}, nil
 }
@@ -266,7 +304,9 @@ func (s *server) AioControllerDelete(ctx context.Context, in *pb.AioControllerDe
 		Name: in.GetHandle().GetValue(),
 	}
 	var result BdevAioDeleteResult
-	err := call("bdev_aio_delete", &params, &result)
+	err := s.registry.aioControllers.remove(ctx, in.GetHandle().GetValue(), func() error {
+		return call(ctx, "bdev_aio_delete", &params, &result)
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -280,38 +320,57 @@ func (s *server) AioControllerDelete(ctx context.Context, in *pb.AioControllerDe
 
 func (s *server) AioControllerUpdate(ctx context.Context, in *pb.AioControllerUpdateRequest) (*pb.AioController, error) {
 	log.Printf("AioControllerUpdate: Received from client: %v", in)
-	params1 := BdevAioDeleteParams{
-		Name: in.GetDevice().GetHandle().GetValue(),
-	}
-	var result1 BdevAioDeleteResult
-	err1 := call("bdev_aio_delete", &params1, &result1)
-	if err1 != nil {
-		log.Printf("error: %v", err1)
-		return nil, err1
-	}
-	log.Printf("Received from SPDK: %v", result1)
-	if !result1 {
-		log.Printf("Could not delete: %v", in)
-	}
-	params2 := BdevAioCreateParams{
-		Name:      in.GetDevice().GetHandle().GetValue(),
-		BlockSize: 512,
-		Filename:  in.GetDevice().GetFilename(),
-	}
-	var result2 BdevAioCreateResult
-	err2 := call("bdev_aio_create", &params2, &result2)
-	if err2 != nil {
-		log.Printf("error: %v", err2)
-		return nil, err2
+	// the device is deleted and created again, keep what it was before
+	var restore func(ctx context.Context) error
+	if old, ok := s.registry.aioControllers.load(in.GetDevice().GetHandle().GetValue()); ok {
+		restore = undoCall("bdev_aio_create", &BdevAioCreateParams{
+			Name:      in.GetDevice().GetHandle().GetValue(),
+			BlockSize: 512,
+			Filename:  old.(*pb.AioController).GetFilename(),
+		})
+	} else {
+		log.Printf("AioControllerUpdate: %s is unknown and cannot be restored if the update fails", in.GetDevice().GetHandle().GetValue())
+	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
+	err := s.registry.aioControllers.put(ctx, in.GetDevice().GetHandle().GetValue(), in.GetDevice(), func() error {
+		params1 := BdevAioDeleteParams{
+			Name: in.GetDevice().GetHandle().GetValue(),
+		}
+		var result1 BdevAioDeleteResult
+		err1 := tx.call("bdev_aio_delete", &params1, &result1, restore)
+		if err1 != nil {
+			return err1
+		}
+		log.Printf("Received from SPDK: %v", result1)
+		if !result1 {
+			log.Printf("Could not delete: %v", in)
+		}
+		params2 := BdevAioCreateParams{
+			Name:      in.GetDevice().GetHandle().GetValue(),
+			BlockSize: 512,
+			Filename:  in.GetDevice().GetFilename(),
+		}
+		var result2 BdevAioCreateResult
+		err2 := tx.call("bdev_aio_create", &params2, &result2, undoCall("bdev_aio_delete", &params1))
+		if err2 != nil {
+			return err2
+		}
+		log.Printf("Received from SPDK: %v", result2)
+		return nil
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result2)
+	tx.commit()
 	return &pb.AioController{}, nil
 }
 
 func (s *server) AioControllerGetList(ctx context.Context, in *pb.AioControllerGetListRequest) (*pb.AioControllerList, error) {
 	log.Printf("AioControllerGetList: Received from client: %v", in)
 	var result []BdevGetBdevsResult
-	err := call("bdev_get_bdevs", nil, &result)
+	err := call(ctx, "bdev_get_bdevs", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -331,7 +390,7 @@ func (s *server) AioControllerGet(ctx context.Context, in *pb.AioControllerGetRe
 		Name: in.GetHandle().GetValue(),
 	}
 	var result []BdevGetBdevsResult
-	err := call("bdev_get_bdevs", &params, &result)
+	err := call(ctx, "bdev_get_bdevs", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -347,23 +406,17 @@ func (s *server) AioControllerGet(ctx context.Context, in *pb.AioControllerGetRe
 
 func (s *server) AioControllerGetStats(ctx context.Context, in *pb.AioControllerGetStatsRequest) (*pb.AioControllerStats, error) {
 	log.Printf("AioControllerGetStats: Received from client: %v", in)
-	params := BdevGetIostatParams{
-		Name: in.GetHandle().GetValue(),
-	}
-	// See https://mholt.github.io/json-to-go/
-	var result BdevGetIostatResult
-	err := call("bdev_get_iostat", &params, &result)
+	stats, err := getVolumeStats(ctx, in.GetHandle().GetValue(), nil)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	if len(result.Bdevs) != 1 {
-		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	encoded, err := encodeVolumeStats(stats)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
-	return &pb.AioControllerStats{Stats: fmt.Sprint(result.Bdevs[0])}, nil
+	return &pb.AioControllerStats{Handle: in.Handle, Stats: encoded}, nil
 }
 
 //////////////////////////////////////////////////////////
@@ -1,29 +1,226 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
 package main
 
 import (
+	"context"
 	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func TestBackEnd_NVMfRemoteControllerConnect(t *testing.T) {
+func testRemoteController() *pb.NVMfRemoteController {
+	return &pb.NVMfRemoteController{
+		Id:      8,
+		Traddr:  "127.0.0.1",
+		Trsvcid: 4444,
+		Subnqn:  "nqn.2016-06.io.spdk:cnode1",
+	}
+}
 
+func TestBackEnd_NVMfRemoteControllerConnect(t *testing.T) {
+	tests := map[string]struct {
+		spdkErr int
+		code    codes.Code
+	}{
+		"valid request":  {0, codes.OK},
+		"already exists": {fakeEEXIST, codes.AlreadyExists},
+		"invalid params": {fakeInvalidParams, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			if tt.spdkErr != 0 {
+				spdk.setError("bdev_nvme_attach_controller", tt.spdkErr, "failed")
+			}
+			_, err := c.remote.NVMfRemoteControllerConnect(context.Background(), &pb.NVMfRemoteControllerConnectRequest{Ctrl: testRemoteController()})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			spdk.mu.Lock()
+			defer spdk.mu.Unlock()
+			if _, ok := spdk.controllers["OpiNvme8"]; ok != (err == nil) {
+				t.Errorf("controller attached %v on error %v", ok, err)
+			}
+		})
+	}
 }
 
 func TestBackEnd_NVMfRemoteControllerDisconnect(t *testing.T) {
-
+	_, c := startBridge(t)
+	ctx := context.Background()
+	if _, err := c.remote.NVMfRemoteControllerConnect(ctx, &pb.NVMfRemoteControllerConnectRequest{Ctrl: testRemoteController()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.remote.NVMfRemoteControllerDisconnect(ctx, &pb.NVMfRemoteControllerDisconnectRequest{Id: 8}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.remote.NVMfRemoteControllerDisconnect(ctx, &pb.NVMfRemoteControllerDisconnectRequest{Id: 8})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
 }
 
 func TestBackEnd_NVMfRemoteControllerReset(t *testing.T) {
-
+	_, c := startBridge(t)
+	if _, err := c.remote.NVMfRemoteControllerReset(context.Background(), &pb.NVMfRemoteControllerResetRequest{Id: 8}); err != nil {
+		t.Error(err)
+	}
 }
 
 func TestBackEnd_NVMfRemoteControllerList(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	if _, err := c.remote.NVMfRemoteControllerConnect(ctx, &pb.NVMfRemoteControllerConnectRequest{Ctrl: testRemoteController()}); err != nil {
+		t.Fatal(err)
+	}
+	response, err := c.remote.NVMfRemoteControllerList(ctx, &pb.NVMfRemoteControllerListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Ctrl) != 1 || response.Ctrl[0].Subnqn != "OpiNvme8" {
+		t.Errorf("unexpected controllers %v", response.Ctrl)
+	}
 
+	spdk.setError("bdev_nvme_get_controllers", -32603, "failed")
+	_, err = c.remote.NVMfRemoteControllerList(ctx, &pb.NVMfRemoteControllerListRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal, got %v", err)
+	}
 }
 
 func TestBackEnd_NVMfRemoteControllerGet(t *testing.T) {
-
+	_, c := startBridge(t)
+	ctx := context.Background()
+	if _, err := c.remote.NVMfRemoteControllerConnect(ctx, &pb.NVMfRemoteControllerConnectRequest{Ctrl: testRemoteController()}); err != nil {
+		t.Fatal(err)
+	}
+	response, err := c.remote.NVMfRemoteControllerGet(ctx, &pb.NVMfRemoteControllerGetRequest{Id: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Ctrl.Subnqn != "OpiNvme8" {
+		t.Errorf("unexpected controller %v", response.Ctrl)
+	}
+	_, err = c.remote.NVMfRemoteControllerGet(ctx, &pb.NVMfRemoteControllerGetRequest{Id: 9})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
 }
 
 func TestBackEnd_NVMfRemoteControllerStats(t *testing.T) {
+	_, c := startBridge(t)
+	if _, err := c.remote.NVMfRemoteControllerStats(context.Background(), &pb.NVMfRemoteControllerStatsRequest{Id: 8}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBackEnd_NullDebug(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	device := &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}
+
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: device}); err != nil {
+		t.Fatal(err)
+	}
+	// a retried create is answered from the registry
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: device}); err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+	if spdk.called("bdev_null_create") != 1 {
+		t.Errorf("expected one bdev_null_create, got %d", spdk.called("bdev_null_create"))
+	}
+	other := &pb.NullDebug{Handle: device.Handle, BlockSize: 4096}
+	_, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: other})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	if _, err := c.null.NullDebugUpdate(ctx, &pb.NullDebugUpdateRequest{Device: device}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := c.null.NullDebugList(ctx, &pb.NullDebugListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Device) != 3 {
+		t.Errorf("unexpected devices %v", list.Device)
+	}
+	get, err := c.null.NullDebugGet(ctx, &pb.NullDebugGetRequest{Handle: &pc.ObjectKey{Value: "Null42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Handle.Value != "Null42" || get.Uuid.Value == "" {
+		t.Errorf("unexpected device %v", get)
+	}
+	stats, err := c.null.NullDebugStats(ctx, &pb.NullDebugStatsRequest{Handle: &pc.ObjectKey{Value: "Null42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Stats == "" {
+		t.Error("expected stats")
+	}
+	if _, err := c.null.NullDebugDelete(ctx, &pb.NullDebugDeleteRequest{Handle: &pc.ObjectKey{Value: "Null42"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.null.NullDebugGet(ctx, &pb.NullDebugGetRequest{Handle: &pc.ObjectKey{Value: "Null42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	_, err = c.null.NullDebugStats(ctx, &pb.NullDebugStatsRequest{Handle: &pc.ObjectKey{Value: "Null42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	spdk.setError("bdev_null_create", fakeInvalidParams, "Invalid parameters")
+	_, err = c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: device})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestBackEnd_AioController(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	device := &pb.AioController{Handle: &pc.ObjectKey{Value: "Aio42"}, Filename: "/tmp/aio_bdev_file"}
+
+	if _, err := c.aio.AioControllerCreate(ctx, &pb.AioControllerCreateRequest{Device: device}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.aio.AioControllerUpdate(ctx, &pb.AioControllerUpdateRequest{Device: device}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := c.aio.AioControllerGetList(ctx, &pb.AioControllerGetListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Device) != 3 {
+		t.Errorf("unexpected devices %v", list.Device)
+	}
+	get, err := c.aio.AioControllerGet(ctx, &pb.AioControllerGetRequest{Handle: &pc.ObjectKey{Value: "Aio42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Handle.Value != "Aio42" {
+		t.Errorf("unexpected device %v", get)
+	}
+	if _, err := c.aio.AioControllerGetStats(ctx, &pb.AioControllerGetStatsRequest{Handle: &pc.ObjectKey{Value: "Aio42"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.aio.AioControllerDelete(ctx, &pb.AioControllerDeleteRequest{Handle: &pc.ObjectKey{Value: "Aio42"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.aio.AioControllerDelete(ctx, &pb.AioControllerDeleteRequest{Handle: &pc.ObjectKey{Value: "Aio42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
 
+	spdk.setError("bdev_aio_create", -1, "Operation not permitted")
+	_, err = c.aio.AioControllerCreate(ctx, &pb.AioControllerCreateRequest{Device: device})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", err)
+	}
 }
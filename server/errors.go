@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// spdkErrorDomain is the ErrorInfo domain of errors reported by SPDK
+const spdkErrorDomain = "spdk.io"
+
+// SpdkError is an error response to a JSON RPC request sent to SPDK
+type SpdkError struct {
+	Method  string
+	Code    int
+	Message string
+}
+
+func (e *SpdkError) Error() string {
+	return fmt.Sprintf("%s: json response error: %s", e.Method, e.Message)
+}
+
+// spdkErrorCodes maps JSON RPC error codes and the negated errno values
+// SPDK returns in their place to gRPC codes and ErrorInfo reasons
+var spdkErrorCodes = map[int]struct {
+	code   codes.Code
+	reason string
+}{
+	-32700: {codes.InvalidArgument, "PARSE_ERROR"},
+	-32600: {codes.InvalidArgument, "INVALID_REQUEST"},
+	-32601: {codes.Unimplemented, "METHOD_NOT_FOUND"},
+	-32602: {codes.InvalidArgument, "INVALID_PARAMS"},
+	-32603: {codes.Internal, "INTERNAL_ERROR"},
+	-1:     {codes.PermissionDenied, "EPERM"},
+	-2:     {codes.NotFound, "ENOENT"},
+	-12:    {codes.ResourceExhausted, "ENOMEM"},
+	-13:    {codes.PermissionDenied, "EACCES"},
+	-16:    {codes.FailedPrecondition, "EBUSY"},
+	-17:    {codes.AlreadyExists, "EEXIST"},
+	-19:    {codes.NotFound, "ENODEV"},
+	-22:    {codes.InvalidArgument, "EINVAL"},
+	-28:    {codes.ResourceExhausted, "ENOSPC"},
+	-95:    {codes.Unimplemented, "EOPNOTSUPP"},
+}
+
+// GRPCStatus converts the error to a gRPC status, so handlers can return
+// it as is. The status carries an ErrorInfo with the SPDK method and code.
+func (e *SpdkError) GRPCStatus() *status.Status {
+	code, reason := codes.Unknown, "UNKNOWN"
+	if m, ok := spdkErrorCodes[e.Code]; ok {
+		code, reason = m.code, m.reason
+	}
+	st := status.New(code, e.Error())
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: spdkErrorDomain,
+		Metadata: map[string]string{
+			"method":  e.Method,
+			"code":    strconv.Itoa(e.Code),
+			"message": e.Message,
+		},
+	})
+	if err != nil {
+		return st
+	}
+	return detailed
+}
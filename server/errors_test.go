@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSpdk_ErrorCodes(t *testing.T) {
+	tests := map[string]struct {
+		code   int
+		want   codes.Code
+		reason string
+	}{
+		"invalid params": {-32602, codes.InvalidArgument, "INVALID_PARAMS"},
+		"method missing": {-32601, codes.Unimplemented, "METHOD_NOT_FOUND"},
+		"exists":         {-17, codes.AlreadyExists, "EEXIST"},
+		"no device":      {-19, codes.NotFound, "ENODEV"},
+		"no entry":       {-2, codes.NotFound, "ENOENT"},
+		"busy":           {-16, codes.FailedPrecondition, "EBUSY"},
+		"unmapped":       {-1234, codes.Unknown, "UNKNOWN"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var request map[string]interface{}
+				_ = json.NewDecoder(r.Body).Decode(&request)
+				_ = json.NewEncoder(w).Encode(map[string]interface{}{
+					"jsonrpc": "2.0",
+					"id":      request["id"],
+					"error":   map[string]interface{}{"code": tt.code, "message": "oops"},
+				})
+			}))
+			defer ts.Close()
+			var err error
+			rpc, err = newTransport(ts.URL, "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = call(context.Background(), "bdev_malloc_create", nil, nil)
+			var spdkErr *SpdkError
+			if !errors.As(err, &spdkErr) {
+				t.Fatalf("expected SpdkError, got %v", err)
+			}
+			if spdkErr.Code != tt.code || spdkErr.Method != "bdev_malloc_create" || spdkErr.Message != "oops" {
+				t.Errorf("unexpected error %+v", spdkErr)
+			}
+			st := status.Convert(err)
+			if st.Code() != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, st.Code())
+			}
+			if len(st.Details()) != 1 {
+				t.Fatalf("expected one detail, got %v", st.Details())
+			}
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			if !ok {
+				t.Fatalf("expected ErrorInfo, got %T", st.Details()[0])
+			}
+			if info.Reason != tt.reason || info.Domain != spdkErrorDomain || info.Metadata["method"] != "bdev_malloc_create" {
+				t.Errorf("unexpected error info %v", info)
+			}
+		})
+	}
+}
@@ -0,0 +1,1283 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// SPDK error codes returned by the fake, see spdk/include/spdk/jsonrpc.h
+// and the negated errno values SPDK uses in their place
+const (
+	fakeInvalidParams = -32602
+	fakeMethodMissing = -32601
+	fakeEEXIST        = -17
+	fakeENODEV        = -19
+)
+
+// fakeError is a JSON RPC error response of the fake SPDK
+type fakeError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *fakeError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Message)
+}
+
+func errExists(name string) *fakeError {
+	return &fakeError{fakeEEXIST, fmt.Sprintf("%s: File exists", name)}
+}
+
+func errNoDevice(name string) *fakeError {
+	return &fakeError{fakeENODEV, fmt.Sprintf("%s: No such device", name)}
+}
+
+func errInvalidParams() *fakeError {
+	return &fakeError{fakeInvalidParams, "Invalid parameters"}
+}
+
+type fakeBdev struct {
+	name      string
+	uuid      string
+	blockSize int64
+	numBlocks int64
+	// I/O counters reported by bdev_get_iostat
+	bytesRead, readOps, bytesWritten, writeOps int
+	readLatencyTicks, writeLatencyTicks        int
+}
+
+type fakeNamespace struct {
+	nsid  int
+	bdev  string
+	nguid string
+	eui64 string
+	uuid  string
+	// seen by hosts only, when not visible to all of them
+	noAutoVisible bool
+	hosts         []string
+}
+
+type fakeSubsystem struct {
+	nqn           string
+	subtype       string
+	serialNumber  string
+	modelNumber   string
+	maxNamespaces int
+	minCntlid     int
+	maxCntlid     int
+	allowAnyHost  bool
+	hosts         []string
+	namespaces    []fakeNamespace
+	listeners     []fakeListener
+	// connected by tests, as if hosts were
+	qpairs []NvmfSubsystemGetQpairsResult
+}
+
+type fakeListener struct {
+	address  NvmfListenAddress
+	anaState string
+	// socket QEMU would connect to, for vfio-user listeners
+	socket net.Listener
+}
+
+type fakeVhost struct {
+	ctrlr   string
+	bdev    string
+	scsi    bool
+	targets map[int]string
+}
+
+type fakeNvmeController struct {
+	name    string
+	trtype  string
+	adrfam  string
+	traddr  string
+	trsvcid string
+	subnqn  string
+}
+
+// fakeSpdk is an in-process SPDK JSON RPC target listening on a unix
+// socket. It models the objects the bridge manages well enough to drive
+// every handler end to end, and can inject errors per method.
+type fakeSpdk struct {
+	mu          sync.Mutex
+	bdevs       map[string]*fakeBdev
+	subsystems  map[string]*fakeSubsystem
+	vhosts      map[string]*fakeVhost
+	controllers map[string]*fakeNvmeController
+	transports  map[string]*NvmfGetTransportsResult
+	errors      map[string]*fakeError
+	failures    map[string]*fakeError
+	calls       map[string]int
+	params      map[string][]json.RawMessage
+	held        map[string]chan struct{}
+	nextUUID    int
+	ticks       int64
+	methods     map[string]func(json.RawMessage) (interface{}, *fakeError)
+}
+
+// newFakeSpdk starts a fake SPDK with two malloc bdevs, like the
+// docker-compose setup, and points the bridge at it
+func newFakeSpdk(t *testing.T) *fakeSpdk {
+	f := &fakeSpdk{
+		bdevs:       map[string]*fakeBdev{},
+		subsystems:  map[string]*fakeSubsystem{},
+		vhosts:      map[string]*fakeVhost{},
+		controllers: map[string]*fakeNvmeController{},
+		transports:  map[string]*NvmfGetTransportsResult{},
+		errors:      map[string]*fakeError{},
+		failures:    map[string]*fakeError{},
+		calls:       map[string]int{},
+		params:      map[string][]json.RawMessage{},
+		held:        map[string]chan struct{}{},
+	}
+	f.methods = map[string]func(json.RawMessage) (interface{}, *fakeError){
+		"bdev_get_bdevs":                        f.bdevGetBdevs,
+		"bdev_get_iostat":                       f.bdevGetIostat,
+		"bdev_malloc_create":                    f.bdevMallocCreate,
+		"bdev_malloc_delete":                    f.bdevDelete,
+		"bdev_null_create":                      f.bdevNullCreate,
+		"bdev_null_delete":                      f.bdevDelete,
+		"bdev_aio_create":                       f.bdevAioCreate,
+		"bdev_aio_delete":                       f.bdevDelete,
+		"bdev_crypto_create":                    f.bdevCryptoCreate,
+		"bdev_crypto_delete":                    f.bdevDelete,
+		"bdev_nvme_attach_controller":           f.bdevNvmeAttachController,
+		"bdev_nvme_detach_controller":           f.bdevNvmeDetachController,
+		"bdev_nvme_get_controllers":             f.bdevNvmeGetControllers,
+		"nvmf_create_subsystem":                 f.nvmfCreateSubsystem,
+		"nvmf_delete_subsystem":                 f.nvmfDeleteSubsystem,
+		"nvmf_get_subsystems":                   f.nvmfGetSubsystems,
+		"nvmf_get_stats":                        f.nvmfGetStats,
+		"nvmf_subsystem_get_qpairs":             f.nvmfSubsystemGetQpairs,
+		"nvmf_subsystem_add_ns":                 f.nvmfSubsystemAddNs,
+		"nvmf_subsystem_remove_ns":              f.nvmfSubsystemRemoveNs,
+		"nvmf_subsystem_add_listener":           f.nvmfSubsystemAddListener,
+		"nvmf_subsystem_remove_listener":        f.nvmfSubsystemRemoveListener,
+		"nvmf_subsystem_get_listeners":          f.nvmfSubsystemGetListeners,
+		"nvmf_subsystem_listener_set_ana_state": f.nvmfSubsystemListenerSetAnaState,
+		"nvmf_subsystem_allow_any_host":         f.nvmfSubsystemAllowAnyHost,
+		"nvmf_create_transport":                 f.nvmfCreateTransport,
+		"nvmf_get_transports":                   f.nvmfGetTransports,
+		"nvmf_subsystem_add_host":               f.nvmfSubsystemAddHost,
+		"nvmf_ns_add_host":                      f.nvmfNsAddHost,
+		"nvmf_ns_remove_host":                   f.nvmfNsRemoveHost,
+		"nvmf_subsystem_remove_host":            f.nvmfSubsystemRemoveHost,
+		"vhost_create_blk_controller":           f.vhostCreateBlkController,
+		"vhost_create_scsi_controller":          f.vhostCreateScsiController,
+		"vhost_delete_controller":               f.vhostDeleteController,
+		"vhost_get_controllers":                 f.vhostGetControllers,
+		"vhost_scsi_controller_add_target":      f.vhostScsiControllerAddTarget,
+		"vhost_scsi_controller_remove_target":   f.vhostScsiControllerRemoveTarget,
+	}
+	f.subsystems["nqn.2014-08.org.nvmexpress.discovery"] = &fakeSubsystem{
+		nqn:          "nqn.2014-08.org.nvmexpress.discovery",
+		subtype:      "Discovery",
+		allowAnyHost: true,
+	}
+	f.addBdev("Malloc0", 512, 131072)
+	f.addBdev("Malloc1", 512, 131072)
+
+	sock := filepath.Join(t.TempDir(), "spdk.sock")
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	rpc = newSocketTransport("unix", sock)
+	*vfiouserDir = t.TempDir()
+	return f
+}
+
+// setError makes every following call of method fail with code
+func (f *fakeSpdk) setError(method string, code int, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors[method] = &fakeError{code, message}
+}
+
+// failNext makes only the next call of method fail with code
+func (f *fakeSpdk) failNext(method string, code int, message string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[method] = &fakeError{code, message}
+}
+
+// clearError stops injecting an error into method
+func (f *fakeSpdk) clearError(method string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.errors, method)
+}
+
+// called returns how many times method was called
+func (f *fakeSpdk) called(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+// sent returns the parameters of every call of method, in order
+func (f *fakeSpdk) sent(method string) []json.RawMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]json.RawMessage(nil), f.params[method]...)
+}
+
+// holdResponses applies the following calls of method right away, but
+// answers them only once release is called
+func (f *fakeSpdk) holdResponses(method string) (release func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	held := make(chan struct{})
+	f.held[method] = held
+	return func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.held, method)
+		close(held)
+	}
+}
+
+func (f *fakeSpdk) serve(conn net.Conn) {
+	defer conn.Close()
+	decoder := json.NewDecoder(conn)
+	encoder := json.NewEncoder(conn)
+	var writing sync.Mutex
+	write := func(response interface{}) error {
+		writing.Lock()
+		defer writing.Unlock()
+		return encoder.Encode(response)
+	}
+	for {
+		var request struct {
+			ID     int32           `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := decoder.Decode(&request); err != nil {
+			return
+		}
+		response := struct {
+			Ver    string      `json:"jsonrpc"`
+			ID     int32       `json:"id"`
+			Result interface{} `json:"result,omitempty"`
+			Error  *fakeError  `json:"error,omitempty"`
+		}{Ver: "2.0", ID: request.ID}
+		response.Result, response.Error = f.handle(request.Method, request.Params)
+		f.mu.Lock()
+		held := f.held[request.Method]
+		f.mu.Unlock()
+		if held != nil {
+			go func() {
+				<-held
+				if err := write(&response); err != nil {
+					log.Printf("fake SPDK: %v", err)
+				}
+			}()
+			continue
+		}
+		if err := write(&response); err != nil {
+			log.Printf("fake SPDK: %v", err)
+			return
+		}
+	}
+}
+
+func (f *fakeSpdk) handle(method string, params json.RawMessage) (interface{}, *fakeError) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+	f.params[method] = append(f.params[method], params)
+	if err, ok := f.errors[method]; ok {
+		return nil, err
+	}
+	if err, ok := f.failures[method]; ok {
+		delete(f.failures, method)
+		return nil, err
+	}
+	handler, ok := f.methods[method]
+	if !ok {
+		return nil, &fakeError{fakeMethodMissing, "Method not found"}
+	}
+	return handler(params)
+}
+
+func decodeParams(params json.RawMessage, v interface{}) *fakeError {
+	if len(params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return errInvalidParams()
+	}
+	return nil
+}
+
+func (f *fakeSpdk) addBdev(name string, blockSize, numBlocks int64) *fakeBdev {
+	f.nextUUID++
+	b := &fakeBdev{
+		name:      name,
+		uuid:      fmt.Sprintf("00000000-0000-4000-8000-%012d", f.nextUUID),
+		blockSize: blockSize,
+		numBlocks: numBlocks,
+	}
+	f.bdevs[name] = b
+	return b
+}
+
+func (f *fakeSpdk) createBdev(name string, blockSize, numBlocks int64) (interface{}, *fakeError) {
+	if name == "" || blockSize == 0 {
+		return nil, errInvalidParams()
+	}
+	if _, ok := f.bdevs[name]; ok {
+		return nil, errExists(name)
+	}
+	f.addBdev(name, blockSize, numBlocks)
+	return name, nil
+}
+
+// isHex tells whether s is empty or n hexadecimal digits
+func isHex(s string, n int) bool {
+	if s == "" {
+		return true
+	}
+	if len(s) != n {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
+
+func sortedKeys(m interface{}) []string {
+	var keys []string
+	switch m := m.(type) {
+	case map[string]*fakeBdev:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*fakeSubsystem:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*fakeVhost:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*fakeNvmeController:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*NvmfGetTransportsResult:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (f *fakeSpdk) bdevGetBdevs(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevGetBdevsParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	result := []BdevGetBdevsResult{}
+	for _, name := range sortedKeys(f.bdevs) {
+		b := f.bdevs[name]
+		if p.Name != "" && p.Name != name {
+			continue
+		}
+		result = append(result, BdevGetBdevsResult{Name: b.name, BlockSize: b.blockSize, NumBlocks: b.numBlocks, UUID: b.uuid})
+	}
+	if p.Name != "" && len(result) == 0 {
+		return nil, errNoDevice(p.Name)
+	}
+	return result, nil
+}
+
+func (f *fakeSpdk) bdevGetIostat(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevGetIostatParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	// a second passes between samples
+	f.ticks += 2000000000
+	result := BdevGetIostatResult{TickRate: 2000000000, Ticks: f.ticks}
+	for _, name := range sortedKeys(f.bdevs) {
+		b := f.bdevs[name]
+		if p.Name != "" && p.Name != name {
+			continue
+		}
+		result.Bdevs = append(result.Bdevs, struct {
+			Name              string `json:"name"`
+			BytesRead         int    `json:"bytes_read"`
+			NumReadOps        int    `json:"num_read_ops"`
+			BytesWritten      int    `json:"bytes_written"`
+			NumWriteOps       int    `json:"num_write_ops"`
+			BytesUnmapped     int    `json:"bytes_unmapped"`
+			NumUnmapOps       int    `json:"num_unmap_ops"`
+			ReadLatencyTicks  int    `json:"read_latency_ticks"`
+			WriteLatencyTicks int    `json:"write_latency_ticks"`
+			UnmapLatencyTicks int    `json:"unmap_latency_ticks"`
+		}{
+			Name:              b.name,
+			BytesRead:         b.bytesRead,
+			NumReadOps:        b.readOps,
+			BytesWritten:      b.bytesWritten,
+			NumWriteOps:       b.writeOps,
+			ReadLatencyTicks:  b.readLatencyTicks,
+			WriteLatencyTicks: b.writeLatencyTicks,
+		})
+	}
+	if p.Name != "" && len(result.Bdevs) == 0 {
+		return nil, errNoDevice(p.Name)
+	}
+	return result, nil
+}
+
+func (f *fakeSpdk) bdevMallocCreate(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevMalloCreateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	return f.createBdev(p.Name, int64(p.BlockSize), int64(p.NumBlocks))
+}
+
+func (f *fakeSpdk) bdevNullCreate(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevNullCreateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	return f.createBdev(p.Name, int64(p.BlockSize), int64(p.NumBlocks))
+}
+
+func (f *fakeSpdk) bdevAioCreate(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevAioCreateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Filename == "" {
+		return nil, errInvalidParams()
+	}
+	return f.createBdev(p.Name, int64(p.BlockSize), 64)
+}
+
+func (f *fakeSpdk) bdevCryptoCreate(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevCryptoCreateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	base, ok := f.bdevs[p.BaseBdevName]
+	if !ok {
+		return nil, errNoDevice(p.BaseBdevName)
+	}
+	return f.createBdev(p.Name, base.blockSize, base.numBlocks)
+}
+
+func (f *fakeSpdk) bdevDelete(params json.RawMessage) (interface{}, *fakeError) {
+	var p struct {
+		Name string `json:"name"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if _, ok := f.bdevs[p.Name]; !ok {
+		return nil, errNoDevice(p.Name)
+	}
+	delete(f.bdevs, p.Name)
+	return true, nil
+}
+
+func (f *fakeSpdk) bdevNvmeAttachController(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevNvmeAttachControllerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Name == "" || p.Address == "" || p.Subsystem == "" {
+		return nil, errInvalidParams()
+	}
+	if _, ok := f.controllers[p.Name]; ok {
+		return nil, errExists(p.Name)
+	}
+	f.controllers[p.Name] = &fakeNvmeController{
+		name:    p.Name,
+		trtype:  p.Type,
+		adrfam:  p.Family,
+		traddr:  p.Address,
+		trsvcid: p.Port,
+		subnqn:  p.Subsystem,
+	}
+	bdev := p.Name + "n1"
+	f.addBdev(bdev, 512, 131072)
+	return []string{bdev}, nil
+}
+
+func (f *fakeSpdk) bdevNvmeDetachController(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevNvmeDetachControllerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if _, ok := f.controllers[p.Name]; !ok {
+		return nil, errNoDevice(p.Name)
+	}
+	delete(f.controllers, p.Name)
+	delete(f.bdevs, p.Name+"n1")
+	return true, nil
+}
+
+func (f *fakeSpdk) bdevNvmeGetControllers(params json.RawMessage) (interface{}, *fakeError) {
+	var p BdevNvmeGetControllerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	result := []BdevNvmeGetControllerResult{}
+	for _, name := range sortedKeys(f.controllers) {
+		c := f.controllers[name]
+		if p.Name != "" && p.Name != name {
+			continue
+		}
+		var r BdevNvmeGetControllerResult
+		r.Name = c.name
+		r.Ctrlrs = make([]struct {
+			State string `json:"state"`
+			Trid  struct {
+				Trtype  string `json:"trtype"`
+				Adrfam  string `json:"adrfam"`
+				Traddr  string `json:"traddr"`
+				Trsvcid string `json:"trsvcid"`
+				Subnqn  string `json:"subnqn"`
+			} `json:"trid"`
+			Cntlid int `json:"cntlid"`
+			Host   struct {
+				Nqn   string `json:"nqn"`
+				Addr  string `json:"addr"`
+				Svcid string `json:"svcid"`
+			} `json:"host"`
+		}, 1)
+		r.Ctrlrs[0].State = "enabled"
+		r.Ctrlrs[0].Trid.Trtype = c.trtype
+		r.Ctrlrs[0].Trid.Adrfam = c.adrfam
+		r.Ctrlrs[0].Trid.Traddr = c.traddr
+		r.Ctrlrs[0].Trid.Trsvcid = c.trsvcid
+		r.Ctrlrs[0].Trid.Subnqn = c.subnqn
+		r.Ctrlrs[0].Cntlid = 1
+		result = append(result, r)
+	}
+	if p.Name != "" && len(result) == 0 {
+		return nil, errNoDevice(p.Name)
+	}
+	return result, nil
+}
+
+func (f *fakeSpdk) nvmfCreateSubsystem(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfCreateSubsystemParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if p.Nqn == "" {
+		return nil, errInvalidParams()
+	}
+	if _, ok := f.subsystems[p.Nqn]; ok {
+		return nil, errExists(p.Nqn)
+	}
+	// SPDK's defaults
+	subsys := &fakeSubsystem{
+		nqn:           p.Nqn,
+		subtype:       "NVMe",
+		serialNumber:  "00000000000000000000",
+		modelNumber:   "SPDK bdev Controller",
+		maxNamespaces: 32,
+		minCntlid:     1,
+		maxCntlid:     65519,
+		allowAnyHost:  p.AllowAnyHost,
+	}
+	if p.SerialNumber != "" {
+		subsys.serialNumber = p.SerialNumber
+	}
+	if p.ModelNumber != "" {
+		subsys.modelNumber = p.ModelNumber
+	}
+	if p.MaxNamespaces != 0 {
+		subsys.maxNamespaces = p.MaxNamespaces
+	}
+	if p.MinCntlid != 0 {
+		subsys.minCntlid = p.MinCntlid
+	}
+	if p.MaxCntlid != 0 {
+		subsys.maxCntlid = p.MaxCntlid
+	}
+	if subsys.minCntlid > subsys.maxCntlid {
+		return nil, errInvalidParams()
+	}
+	f.subsystems[p.Nqn] = subsys
+	return true, nil
+}
+
+func (f *fakeSpdk) nvmfDeleteSubsystem(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfDeleteSubsystemParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, errInvalidParams()
+	}
+	for i := range s.listeners {
+		s.listeners[i].close()
+	}
+	delete(f.subsystems, p.Nqn)
+	return true, nil
+}
+
+func (f *fakeSpdk) nvmfGetSubsystems(params json.RawMessage) (interface{}, *fakeError) {
+	result := []NvmfGetSubsystemsResult{}
+	for _, nqn := range sortedKeys(f.subsystems) {
+		s := f.subsystems[nqn]
+		r := NvmfGetSubsystemsResult{
+			Nqn:             s.nqn,
+			Subtype:         s.subtype,
+			ListenAddresses: []NvmfListenAddress{},
+			AllowAnyHost:    s.allowAnyHost,
+			Hosts:           []NvmfSubsystemHost{},
+		}
+		for _, l := range s.listeners {
+			r.ListenAddresses = append(r.ListenAddresses, l.address)
+		}
+		for _, host := range s.hosts {
+			r.Hosts = append(r.Hosts, NvmfSubsystemHost{Nqn: host})
+		}
+		if s.subtype == "NVMe" {
+			r.SerialNumber = s.serialNumber
+			r.ModelNumber = s.modelNumber
+			r.MaxNamespaces = s.maxNamespaces
+			r.MinCntlid = s.minCntlid
+			r.MaxCntlid = s.maxCntlid
+			r.Namespaces = make([]NvmfSubsystemNamespace, len(s.namespaces))
+			for i, ns := range s.namespaces {
+				r.Namespaces[i] = NvmfSubsystemNamespace{
+					Nsid:     ns.nsid,
+					BdevName: ns.bdev,
+					Name:     ns.bdev,
+					Nguid:    ns.nguid,
+					Eui64:    ns.eui64,
+					UUID:     ns.uuid,
+				}
+			}
+		}
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+func (f *fakeSpdk) nvmfGetStats(params json.RawMessage) (interface{}, *fakeError) {
+	result := NvmfGetSubsystemStatsResult{TickRate: 2000000000}
+	result.PollGroups = make([]struct {
+		Name               string `json:"name"`
+		AdminQpairs        int    `json:"admin_qpairs"`
+		IoQpairs           int    `json:"io_qpairs"`
+		CurrentAdminQpairs int    `json:"current_admin_qpairs"`
+		CurrentIoQpairs    int    `json:"current_io_qpairs"`
+		PendingBdevIo      int    `json:"pending_bdev_io"`
+		Transports         []struct {
+			Trtype string `json:"trtype"`
+		} `json:"transports"`
+	}, 1)
+	// one poll group serves every queue pair
+	g := &result.PollGroups[0]
+	g.Name = "nvmf_tgt_poll_group_0"
+	for _, subsys := range f.subsystems {
+		for _, q := range subsys.qpairs {
+			if q.Qid == 0 {
+				g.AdminQpairs++
+			} else {
+				g.IoQpairs++
+			}
+		}
+	}
+	g.CurrentAdminQpairs, g.CurrentIoQpairs = g.AdminQpairs, g.IoQpairs
+	return result, nil
+}
+
+func (f *fakeSpdk) nvmfSubsystemGetQpairs(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemGetQpairsParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	return append([]NvmfSubsystemGetQpairsResult{}, s.qpairs...), nil
+}
+
+func (f *fakeSpdk) nvmfSubsystemAddNs(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemAddNsParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok || s.subtype != "NVMe" {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	bdev, ok := f.bdevs[p.Namespace.BdevName]
+	if !ok {
+		return nil, errInvalidParams()
+	}
+	if len(s.namespaces) >= s.maxNamespaces || p.Namespace.Nsid > s.maxNamespaces {
+		return nil, errInvalidParams()
+	}
+	if !isHex(p.Namespace.Nguid, 32) || !isHex(p.Namespace.Eui64, 16) {
+		return nil, errInvalidParams()
+	}
+	nsid := p.Namespace.Nsid
+	for _, ns := range s.namespaces {
+		if nsid != 0 && ns.nsid == nsid {
+			return nil, errInvalidParams()
+		}
+	}
+	if nsid == 0 {
+		nsid = 1
+		for _, ns := range s.namespaces {
+			if ns.nsid >= nsid {
+				nsid = ns.nsid + 1
+			}
+		}
+	}
+	// SPDK takes the UUID of the bdev and the NGUID from the UUID
+	ns := fakeNamespace{nsid: nsid, bdev: bdev.name, nguid: p.Namespace.Nguid, eui64: p.Namespace.Eui64, uuid: p.Namespace.UUID, noAutoVisible: p.Namespace.NoAutoVisible}
+	if ns.uuid == "" {
+		ns.uuid = bdev.uuid
+	}
+	if ns.nguid == "" {
+		ns.nguid = strings.ToUpper(strings.ReplaceAll(ns.uuid, "-", ""))
+	}
+	s.namespaces = append(s.namespaces, ns)
+	return nsid, nil
+}
+
+func (f *fakeSpdk) nvmfSubsystemRemoveNs(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemRemoveNsParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	for i, ns := range s.namespaces {
+		if ns.nsid == p.Nsid {
+			s.namespaces = append(s.namespaces[:i], s.namespaces[i+1:]...)
+			return true, nil
+		}
+	}
+	return nil, errInvalidParams()
+}
+
+// listener finds the listener on addr, which SPDK compares ignoring the
+// case of the names
+func (s *fakeSubsystem) listener(addr NvmfListenAddress) int {
+	for i, l := range s.listeners {
+		if sameListenAddress(l.address, addr) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (f *fakeSpdk) nvmfSubsystemAddListener(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemAddListenerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	if p.ListenAddress.Trtype == "" || p.ListenAddress.Traddr == "" {
+		return nil, errInvalidParams()
+	}
+	if _, ok := f.transports[strings.ToUpper(p.ListenAddress.Trtype)]; !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find " + p.ListenAddress.Trtype + " transport"}
+	}
+	if s.listener(p.ListenAddress) >= 0 {
+		return nil, errExists(p.ListenAddress.Traddr)
+	}
+	l := fakeListener{address: p.ListenAddress, anaState: "optimized"}
+	if strings.EqualFold(p.ListenAddress.Trtype, "VFIOUSER") {
+		socket, err := f.listenVfioUser(p.ListenAddress.Traddr)
+		if err != nil {
+			return nil, err
+		}
+		l.socket = socket
+	}
+	s.listeners = append(s.listeners, l)
+	return true, nil
+}
+
+// listenVfioUser creates the socket cntrl in dir like SPDK's vfio-user
+// transport, which fails when dir is missing or taken by another endpoint
+func (f *fakeSpdk) listenVfioUser(dir string) (net.Listener, *fakeError) {
+	for _, s := range f.subsystems {
+		for _, l := range s.listeners {
+			if l.socket != nil && l.address.Traddr == dir {
+				return nil, &fakeError{fakeInvalidParams, "Unable to listen on address " + dir}
+			}
+		}
+	}
+	socket, err := net.Listen("unix", filepath.Join(dir, "cntrl"))
+	if err != nil {
+		return nil, &fakeError{fakeInvalidParams, err.Error()}
+	}
+	return socket, nil
+}
+
+// close removes the vfio-user socket of l, if any
+func (l *fakeListener) close() {
+	if l.socket != nil {
+		l.socket.Close()
+	}
+}
+
+func (f *fakeSpdk) nvmfSubsystemRemoveListener(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemRemoveListenerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	i := s.listener(p.ListenAddress)
+	if i < 0 {
+		return nil, errInvalidParams()
+	}
+	s.listeners[i].close()
+	s.listeners = append(s.listeners[:i], s.listeners[i+1:]...)
+	return true, nil
+}
+
+func (f *fakeSpdk) nvmfSubsystemListenerSetAnaState(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemListenerSetAnaStateParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	i := s.listener(p.ListenAddress)
+	if i < 0 {
+		return nil, errInvalidParams()
+	}
+	switch p.AnaState {
+	case "optimized", "non_optimized", "inaccessible":
+		s.listeners[i].anaState = p.AnaState
+		return true, nil
+	}
+	return nil, errInvalidParams()
+}
+
+func (f *fakeSpdk) nvmfSubsystemGetListeners(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemGetListenersParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	result := []NvmfSubsystemGetListenersResult{}
+	for _, l := range s.listeners {
+		r := NvmfSubsystemGetListenersResult{Address: l.address}
+		r.AnaStates = append(r.AnaStates, struct {
+			AnaGroup int    `json:"ana_group"`
+			AnaState string `json:"ana_state"`
+		}{1, l.anaState})
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// namespace finds the namespace nsid of the subsystem nqn
+func (f *fakeSpdk) namespace(nqn string, nsid int) (*fakeNamespace, *fakeError) {
+	s, ok := f.subsystems[nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + nqn}
+	}
+	for i := range s.namespaces {
+		if s.namespaces[i].nsid == nsid {
+			return &s.namespaces[i], nil
+		}
+	}
+	return nil, errInvalidParams()
+}
+
+func (f *fakeSpdk) nvmfNsAddHost(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfNsAddHostParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	ns, err := f.namespace(p.Nqn, p.Nsid)
+	if err != nil {
+		return nil, err
+	}
+	// a namespace visible to all hosts has no hosts of its own
+	if !ns.noAutoVisible || !strings.HasPrefix(p.Host, "nqn.") {
+		return nil, errInvalidParams()
+	}
+	for _, host := range ns.hosts {
+		if host == p.Host {
+			return nil, errInvalidParams()
+		}
+	}
+	ns.hosts = append(ns.hosts, p.Host)
+	return true, nil
+}
+
+func (f *fakeSpdk) nvmfNsRemoveHost(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfNsRemoveHostParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	ns, err := f.namespace(p.Nqn, p.Nsid)
+	if err != nil {
+		return nil, err
+	}
+	for i, host := range ns.hosts {
+		if host == p.Host {
+			ns.hosts = append(ns.hosts[:i], ns.hosts[i+1:]...)
+			return true, nil
+		}
+	}
+	return nil, errInvalidParams()
+}
+
+func (f *fakeSpdk) nvmfSubsystemAllowAnyHost(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemAllowAnyHostParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	s.allowAnyHost = p.AllowAnyHost
+	return true, nil
+}
+
+// nvmfSubsystemAddHost succeeds for a host already allowed, like SPDK
+func (f *fakeSpdk) nvmfSubsystemAddHost(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemAddHostParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	if !strings.HasPrefix(p.Host, "nqn.") {
+		return nil, errInvalidParams()
+	}
+	for _, host := range s.hosts {
+		if host == p.Host {
+			return true, nil
+		}
+	}
+	s.hosts = append(s.hosts, p.Host)
+	return true, nil
+}
+
+func (f *fakeSpdk) nvmfSubsystemRemoveHost(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfSubsystemRemoveHostParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	s, ok := f.subsystems[p.Nqn]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Unable to find subsystem with NQN " + p.Nqn}
+	}
+	for i, host := range s.hosts {
+		if host == p.Host {
+			s.hosts = append(s.hosts[:i], s.hosts[i+1:]...)
+			return true, nil
+		}
+	}
+	return nil, errInvalidParams()
+}
+
+// fakeTransportDefaults are SPDK's defaults per transport type
+var fakeTransportDefaults = map[string]NvmfGetTransportsResult{
+	"TCP":      {MaxQueueDepth: 128, MaxIoQpairsPerCtrlr: 127, InCapsuleDataSize: 4096, MaxIoSize: 131072, IoUnitSize: 131072, NumSharedBuffers: 511},
+	"RDMA":     {MaxQueueDepth: 128, MaxIoQpairsPerCtrlr: 127, InCapsuleDataSize: 4096, MaxIoSize: 131072, IoUnitSize: 8192, NumSharedBuffers: 4095},
+	"VFIOUSER": {MaxQueueDepth: 256, MaxIoQpairsPerCtrlr: 127, InCapsuleDataSize: 0, MaxIoSize: 131072, IoUnitSize: 131072, NumSharedBuffers: 511},
+}
+
+func (f *fakeSpdk) nvmfCreateTransport(params json.RawMessage) (interface{}, *fakeError) {
+	var p NvmfCreateTransportParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	trtype := strings.ToUpper(p.Trtype)
+	t, ok := fakeTransportDefaults[trtype]
+	if !ok {
+		return nil, &fakeError{fakeInvalidParams, "Transport type '" + p.Trtype + "' unavailable"}
+	}
+	if _, ok := f.transports[trtype]; ok {
+		return nil, &fakeError{-32603, "Transport type '" + p.Trtype + "' already exists"}
+	}
+	t.Trtype = trtype
+	if p.IoUnitSize != 0 {
+		t.IoUnitSize = p.IoUnitSize
+	}
+	if p.MaxIoQpairsPerCtrlr != 0 {
+		t.MaxIoQpairsPerCtrlr = p.MaxIoQpairsPerCtrlr
+	}
+	if p.InCapsuleDataSize != nil {
+		t.InCapsuleDataSize = *p.InCapsuleDataSize
+	}
+	if p.NumSharedBuffers != 0 {
+		t.NumSharedBuffers = p.NumSharedBuffers
+	}
+	t.SockPriority = p.SockPriority
+	f.transports[trtype] = &t
+	return true, nil
+}
+
+func (f *fakeSpdk) nvmfGetTransports(params json.RawMessage) (interface{}, *fakeError) {
+	result := []NvmfGetTransportsResult{}
+	for _, trtype := range sortedKeys(f.transports) {
+		result = append(result, *f.transports[trtype])
+	}
+	return result, nil
+}
+
+func (f *fakeSpdk) vhostCreateBlkController(params json.RawMessage) (interface{}, *fakeError) {
+	var p VhostCreateBlkControllerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if _, ok := f.vhosts[p.Ctrlr]; ok {
+		return nil, errExists(p.Ctrlr)
+	}
+	if _, ok := f.bdevs[p.DevName]; !ok {
+		return nil, errNoDevice(p.DevName)
+	}
+	f.vhosts[p.Ctrlr] = &fakeVhost{ctrlr: p.Ctrlr, bdev: p.DevName}
+	return true, nil
+}
+
+func (f *fakeSpdk) vhostCreateScsiController(params json.RawMessage) (interface{}, *fakeError) {
+	var p VhostCreateScsiControllerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if _, ok := f.vhosts[p.Ctrlr]; ok {
+		return nil, errExists(p.Ctrlr)
+	}
+	f.vhosts[p.Ctrlr] = &fakeVhost{ctrlr: p.Ctrlr, scsi: true, targets: map[int]string{}}
+	return true, nil
+}
+
+func (f *fakeSpdk) vhostDeleteController(params json.RawMessage) (interface{}, *fakeError) {
+	var p VhostDeleteControllerParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	if _, ok := f.vhosts[p.Ctrlr]; !ok {
+		return nil, errNoDevice(p.Ctrlr)
+	}
+	delete(f.vhosts, p.Ctrlr)
+	return true, nil
+}
+
+func (f *fakeSpdk) vhostGetControllers(params json.RawMessage) (interface{}, *fakeError) {
+	var p VhostGetControllersParams
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	result := []VhostGetControllersResult{}
+	for _, name := range sortedKeys(f.vhosts) {
+		v := f.vhosts[name]
+		if p.Name != "" && p.Name != name {
+			continue
+		}
+		r := VhostGetControllersResult{
+			Ctrlr:   v.ctrlr,
+			Cpumask: "0x1",
+			Socket:  "/var/tmp/" + v.ctrlr,
+		}
+		r.BackendSpecific.Block.Bdev = v.bdev
+		result = append(result, r)
+	}
+	if p.Name != "" && len(result) == 0 {
+		return nil, errNoDevice(p.Name)
+	}
+	return result, nil
+}
+
+func (f *fakeSpdk) vhostScsiControllerAddTarget(params json.RawMessage) (interface{}, *fakeError) {
+	var p struct {
+		Ctrlr string `json:"ctrlr"`
+		Num   int    `json:"scsi_target_num"`
+		Bdev  string `json:"bdev_name"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	v, ok := f.vhosts[p.Ctrlr]
+	if !ok || !v.scsi {
+		return nil, errNoDevice(p.Ctrlr)
+	}
+	if _, ok := f.bdevs[p.Bdev]; !ok {
+		return nil, errNoDevice(p.Bdev)
+	}
+	if _, ok := v.targets[p.Num]; ok {
+		return nil, errExists(fmt.Sprint(p.Num))
+	}
+	v.targets[p.Num] = p.Bdev
+	return p.Num, nil
+}
+
+func (f *fakeSpdk) vhostScsiControllerRemoveTarget(params json.RawMessage) (interface{}, *fakeError) {
+	var p struct {
+		Ctrlr string `json:"ctrlr"`
+		Num   int    `json:"scsi_target_num"`
+	}
+	if err := decodeParams(params, &p); err != nil {
+		return nil, err
+	}
+	v, ok := f.vhosts[p.Ctrlr]
+	if !ok || !v.scsi {
+		return nil, errNoDevice(p.Ctrlr)
+	}
+	if _, ok := v.targets[p.Num]; !ok {
+		return nil, errNoDevice(fmt.Sprint(p.Num))
+	}
+	delete(v.targets, p.Num)
+	return true, nil
+}
+
+// bridgeClients are gRPC clients of every service the bridge serves,
+// along with the server behind them
+type bridgeClients struct {
+	server *server
+
+	nvme       pb.FrontendNvmeServiceClient
+	virtioBlk  pb.FrontendVirtioBlkServiceClient
+	virtioScsi pb.FrontendVirtioScsiServiceClient
+	remote     pb.NVMfRemoteControllerServiceClient
+	null       pb.NullDebugServiceClient
+	aio        pb.AioControllerServiceClient
+	middleend  pb.MiddleendServiceClient
+	listener   bridge.NVMfListenerServiceClient
+	host       bridge.NVMfHostServiceClient
+	transport  bridge.NVMfTransportServiceClient
+	visibility bridge.NVMeNamespaceVisibilityServiceClient
+	stats      bridge.StatsServiceClient
+	subsystem  bridge.NVMfSubsystemServiceClient
+	volume     bridge.VolumeStatsServiceClient
+}
+
+// startBridge serves the bridge over an in-memory gRPC connection backed
+// by a fresh fake SPDK
+func startBridge(t *testing.T) (*fakeSpdk, *bridgeClients) {
+	spdk := newFakeSpdk(t)
+	return spdk, serveBridge(t)
+}
+
+// serveBridge serves the bridge over an in-memory gRPC connection to
+// whatever rpc currently points at, with all bridge state reset
+func serveBridge(t *testing.T) *bridgeClients {
+	srv, err := newServer(newMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serveServer(t, srv)
+}
+
+// serveServer serves srv over an in-memory gRPC connection
+func serveServer(t *testing.T, srv *server) *bridgeClients {
+	lis := bufconn.Listen(1024 * 1024)
+	cache := newRequestCache(*requestIDWindow)
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(metricsUnaryInterceptor, cache.unaryInterceptor),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor),
+	)
+	pb.RegisterFrontendNvmeServiceServer(s, srv)
+	pb.RegisterNVMfRemoteControllerServiceServer(s, srv)
+	pb.RegisterFrontendVirtioBlkServiceServer(s, srv)
+	pb.RegisterFrontendVirtioScsiServiceServer(s, srv)
+	pb.RegisterNullDebugServiceServer(s, srv)
+	pb.RegisterAioControllerServiceServer(s, srv)
+	pb.RegisterMiddleendServiceServer(s, srv)
+	bridge.RegisterNVMfListenerServiceServer(s, srv)
+	bridge.RegisterNVMfHostServiceServer(s, srv)
+	bridge.RegisterNVMfTransportServiceServer(s, srv)
+	bridge.RegisterNVMeNamespaceVisibilityServiceServer(s, srv)
+	bridge.RegisterStatsServiceServer(s, srv)
+	bridge.RegisterNVMfSubsystemServiceServer(s, srv)
+	bridge.RegisterVolumeStatsServiceServer(s, srv)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			log.Printf("bufconn server: %v", err)
+		}
+	}()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &bridgeClients{
+		server:     srv,
+		nvme:       pb.NewFrontendNvmeServiceClient(conn),
+		virtioBlk:  pb.NewFrontendVirtioBlkServiceClient(conn),
+		virtioScsi: pb.NewFrontendVirtioScsiServiceClient(conn),
+		remote:     pb.NewNVMfRemoteControllerServiceClient(conn),
+		null:       pb.NewNullDebugServiceClient(conn),
+		aio:        pb.NewAioControllerServiceClient(conn),
+		middleend:  pb.NewMiddleendServiceClient(conn),
+		listener:   bridge.NewNVMfListenerServiceClient(conn),
+		host:       bridge.NewNVMfHostServiceClient(conn),
+		transport:  bridge.NewNVMfTransportServiceClient(conn),
+		visibility: bridge.NewNVMeNamespaceVisibilityServiceClient(conn),
+		stats:      bridge.NewStatsServiceClient(conn),
+		subsystem:  bridge.NewNVMfSubsystemServiceClient(conn),
+		volume:     bridge.NewVolumeStatsServiceClient(conn),
+	}
+}
@@ -5,37 +5,67 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 
 	pc "github.com/opiproject/opi-api/common/v1/gen/go"
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
 	"github.com/ulule/deepcopier"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
+	bridge "opi.storage.v1/proto/gen/go"
 )
 
 // ////////////////////////////////////////////////////////
-var subsystems = map[string]*pb.NVMeSubsystem{}
 
 func (s *server) CreateNVMeSubsystem(ctx context.Context, in *pb.CreateNVMeSubsystemRequest) (*pb.NVMeSubsystem, error) {
 	log.Printf("CreateNVMeSubsystem: Received from client: %v", in)
-	params := NvmfCreateSubsystemParams{
-		Nqn:          in.Subsystem.Spec.Nqn,
-		SerialNumber: "SPDK0",
-		AllowAnyHost: true,
+	return s.createNVMeSubsystem(ctx, in.Subsystem, s.registry.cntlidRange(in.Subsystem.Spec.Id.Value))
+}
+
+// createNVMeSubsystem creates subsys with the controller ID range of
+// cntlids. A subsystem without a serial number gets one derived from its
+// NQN, as SPDK would give all of them the same.
+func (s *server) createNVMeSubsystem(ctx context.Context, subsys *pb.NVMeSubsystem, cntlids *bridge.NVMfSubsystem) (*pb.NVMeSubsystem, error) {
+	if subsys.Spec.SerialNumber == "" {
+		subsys = proto.Clone(subsys).(*pb.NVMeSubsystem)
+		subsys.Spec.SerialNumber = defaultSerialNumber(subsys.Spec.Nqn)
 	}
+	params := NvmfCreateSubsystemParams{
+		Nqn:           subsys.Spec.Nqn,
+		SerialNumber:  subsys.Spec.SerialNumber,
+		ModelNumber:   subsys.Spec.ModelNumber,
+		MaxNamespaces: int(subsys.Spec.MaxNamespaces),
+		AllowAnyHost:  s.registry.allowAnyHost(subsys.Spec.Id.Value),
+		MinCntlid:     int(cntlids.MinCntlid),
+		MaxCntlid:     int(cntlids.MaxCntlid),
+	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
 	var result NvmfCreateSubsystemResult
-	err := call("nvmf_create_subsystem", &params, &result)
+	existing, err := s.registry.subsystems.create(ctx, subsys.Spec.Id.Value, subsys, recreating(ctx), func() error {
+		return tx.call("nvmf_create_subsystem", &params, &result,
+			undoCall("nvmf_delete_subsystem", &NvmfDeleteSubsystemParams{Nqn: params.Nqn}))
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	subsystems[in.Subsystem.Spec.Id.Value] = in.Subsystem
+	tx.commit()
+	if existing != nil {
+		log.Printf("CreateNVMeSubsystem: %s already exists", subsys.Spec.Id.Value)
+		return existing.(*pb.NVMeSubsystem), nil
+	}
 	log.Printf("Received from SPDK: %v", result)
 	response := &pb.NVMeSubsystem{}
-	err = deepcopier.Copy(in.Subsystem).To(response)
+	err = deepcopier.Copy(subsys).To(response)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -43,19 +73,34 @@ func (s *server) CreateNVMeSubsystem(ctx context.Context, in *pb.CreateNVMeSubsy
 	return response, nil
 }
 
+// defaultSerialNumber derives a serial number from nqn, in the 20
+// characters the NVMe specification allows
+func defaultSerialNumber(nqn string) string {
+	sum := sha256.Sum256([]byte(nqn))
+	return "OPI" + strings.ToUpper(hex.EncodeToString(sum[:]))[:17]
+}
+
 func (s *server) DeleteNVMeSubsystem(ctx context.Context, in *pb.DeleteNVMeSubsystemRequest) (*emptypb.Empty, error) {
 	log.Printf("DeleteNVMeSubsystem: Received from client: %v", in)
-	subsys, ok := subsystems[in.SubsystemId.Value]
+	subsys, ok := s.registry.subsystem(in.SubsystemId.Value)
 	if !ok {
 		err := fmt.Errorf("unable to find key %s", in.SubsystemId)
 		log.Printf("error: %v", err)
 		return nil, err
 	}
+	// SPDK would take them along, but the bridge would keep answering for them
+	if children := s.registry.subsystemChildren(subsys.Spec.Id.Value); len(children) != 0 {
+		err := status.Errorf(codes.FailedPrecondition, "subsystem %s still has %s, delete them first", subsys.Spec.Id.Value, strings.Join(children, ", "))
+		log.Printf("error: %v", err)
+		return nil, err
+	}
 	params := NvmfDeleteSubsystemParams{
 		Nqn: subsys.Spec.Nqn,
 	}
 	var result NvmfDeleteSubsystemResult
-	err := call("nvmf_delete_subsystem", &params, &result)
+	err := s.registry.subsystems.remove(ctx, subsys.Spec.Id.Value, func() error {
+		return call(ctx, "nvmf_delete_subsystem", &params, &result)
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -64,15 +109,21 @@ func (s *server) DeleteNVMeSubsystem(ctx context.Context, in *pb.DeleteNVMeSubsy
 	if !result {
 		log.Printf("Could not delete: %v", in)
 	}
-	delete(subsystems, subsys.Spec.Id.Value)
+	if err := s.registry.cntlidRanges.remove(ctx, subsys.Spec.Id.Value, nil); err != nil {
+		log.Printf("error: %v", err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
 func (s *server) UpdateNVMeSubsystem(ctx context.Context, in *pb.UpdateNVMeSubsystemRequest) (*pb.NVMeSubsystem, error) {
 	log.Printf("UpdateNVMeSubsystem: Received from client: %v", in)
-	subsystems[in.Subsystem.Spec.Id.Value] = in.Subsystem
+	err := s.registry.subsystems.put(ctx, in.Subsystem.Spec.Id.Value, in.Subsystem, nil)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
 	response := &pb.NVMeSubsystem{}
-	err := deepcopier.Copy(in.Subsystem).To(response)
+	err = deepcopier.Copy(in.Subsystem).To(response)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -83,7 +134,7 @@ func (s *server) UpdateNVMeSubsystem(ctx context.Context, in *pb.UpdateNVMeSubsy
 func (s *server) ListNVMeSubsystem(ctx context.Context, in *pb.ListNVMeSubsystemRequest) (*pb.ListNVMeSubsystemResponse, error) {
 	log.Printf("ListNVMeSubsystem: Received from client: %v", in)
 	var result []NvmfGetSubsystemsResult
-	err := call("nvmf_get_subsystems", nil, &result)
+	err := call(ctx, "nvmf_get_subsystems", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -92,14 +143,16 @@ func (s *server) ListNVMeSubsystem(ctx context.Context, in *pb.ListNVMeSubsystem
 	Blobarray := make([]*pb.NVMeSubsystem, len(result))
 	for i := range result {
 		r := &result[i]
-		Blobarray[i] = &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{Nqn: r.Nqn}}
+		Blobarray[i] = &pb.NVMeSubsystem{Spec: subsystemSpec(r)}
 	}
 	return &pb.ListNVMeSubsystemResponse{Subsystems: Blobarray}, nil
 }
 
+// GetNVMeSubsystem reports the fields of the OPI spec only, the listeners
+// and allowed hosts of the subsystem are in GetNVMfSubsystem
 func (s *server) GetNVMeSubsystem(ctx context.Context, in *pb.GetNVMeSubsystemRequest) (*pb.NVMeSubsystem, error) {
 	log.Printf("GetNVMeSubsystem: Received from client: %v", in)
-	subsys, ok := subsystems[in.SubsystemId.Value]
+	subsys, ok := s.registry.subsystem(in.SubsystemId.Value)
 	if !ok {
 		err := fmt.Errorf("unable to find key %s", in.SubsystemId.Value)
 		log.Printf("error: %v", err)
@@ -107,7 +160,7 @@ func (s *server) GetNVMeSubsystem(ctx context.Context, in *pb.GetNVMeSubsystemRe
 	}
 
 	var result []NvmfGetSubsystemsResult
-	err := call("nvmf_get_subsystems", nil, &result)
+	err := call(ctx, "nvmf_get_subsystems", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -117,7 +170,9 @@ func (s *server) GetNVMeSubsystem(ctx context.Context, in *pb.GetNVMeSubsystemRe
 	for i := range result {
 		r := &result[i]
 		if r.Nqn == subsys.Spec.Nqn {
-			return &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{Nqn: r.Nqn}}, nil
+			spec := subsystemSpec(r)
+			spec.Id = subsys.Spec.Id
+			return &pb.NVMeSubsystem{Spec: spec}, nil
 		}
 	}
 	msg := fmt.Sprintf("Could not find NQN: %s", subsys.Spec.Nqn)
@@ -125,26 +180,95 @@ func (s *server) GetNVMeSubsystem(ctx context.Context, in *pb.GetNVMeSubsystemRe
 	return nil, status.Errorf(codes.InvalidArgument, msg)
 }
 
+// subsystemSpec reports the spec of a subsystem as SPDK has it
+func subsystemSpec(r *NvmfGetSubsystemsResult) *pb.NVMeSubsystemSpec {
+	return &pb.NVMeSubsystemSpec{
+		Nqn:           r.Nqn,
+		SerialNumber:  r.SerialNumber,
+		ModelNumber:   r.ModelNumber,
+		MaxNamespaces: int64(r.MaxNamespaces),
+	}
+}
+
+// NVMeSubsystemStats counts the queue pairs of the subsystem, in JSON
 func (s *server) NVMeSubsystemStats(ctx context.Context, in *pb.NVMeSubsystemStatsRequest) (*pb.NVMeSubsystemStatsResponse, error) {
 	log.Printf("NVMeSubsystemStats: Received from client: %v", in)
-	var result NvmfGetSubsystemStatsResult
-	err := call("nvmf_get_stats", nil, &result)
+	subsys, ok := s.registry.subsystem(in.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	stats, err := getQpairStats(ctx, subsys.Spec.Nqn, nil)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	return &pb.NVMeSubsystemStatsResponse{Stats: fmt.Sprint(result.TickRate)}, nil
+	encoded, err := marshalStats(stats)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return &pb.NVMeSubsystemStatsResponse{Stats: encoded}, nil
 }
 
 // ////////////////////////////////////////////////////////
-var controllers = map[string]*pb.NVMeController{}
 
+// CreateNVMeController provisions a vfio-user endpoint: a listener of the
+// subsystem on the controller's socket directory, which QEMU connects to
+// in order to emulate the controller at its PCIe function
 func (s *server) CreateNVMeController(ctx context.Context, in *pb.CreateNVMeControllerRequest) (*pb.NVMeController, error) {
 	log.Printf("Received from client: %v", in.Controller)
-	controllers[in.Controller.Spec.Id.Value] = in.Controller
+	subsys, ok := s.registry.subsystem(in.Controller.Spec.SubsystemId.GetValue())
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.Controller.Spec.SubsystemId.GetValue())
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	addr, err := controllerAddress(in.Controller.Spec)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	params := NvmfSubsystemAddListenerParams{
+		Nqn:           subsys.Spec.Nqn,
+		ListenAddress: addr,
+	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
+	var release func()
+	defer func() {
+		if release != nil {
+			release()
+		}
+	}()
+	var result NvmfSubsystemAddListenerResult
+	existing, err := s.registry.controllers.create(ctx, in.Controller.Spec.Id.Value, in.Controller, recreating(ctx), func() error {
+		var err error
+		if release, err = s.claimEndpoint(in.Controller.Spec); err != nil {
+			return err
+		}
+		if err := ensureVfioUserTransport(ctx); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(addr.Traddr, 0o755); err != nil {
+			return err
+		}
+		return tx.call("nvmf_subsystem_add_listener", &params, &result,
+			undoCall("nvmf_subsystem_remove_listener", &NvmfSubsystemRemoveListenerParams{Nqn: params.Nqn, ListenAddress: addr}))
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("CreateNVMeController: %s already exists", in.Controller.Spec.Id.Value)
+		return existing.(*pb.NVMeController), nil
+	}
+	log.Printf("Received from SPDK: %v", result)
 	response := &pb.NVMeController{}
-	err := deepcopier.Copy(in.Controller).To(response)
+	err = deepcopier.Copy(in.Controller).To(response)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -154,19 +278,67 @@ func (s *server) CreateNVMeController(ctx context.Context, in *pb.CreateNVMeCont
 
 func (s *server) DeleteNVMeController(ctx context.Context, in *pb.DeleteNVMeControllerRequest) (*emptypb.Empty, error) {
 	log.Printf("Received from client: %v", in.ControllerId)
-	controller, ok := controllers[in.ControllerId.Value]
+	controller, ok := s.registry.controller(in.ControllerId.Value)
 	if !ok {
 		return nil, fmt.Errorf("error finding controller %s", in.ControllerId.Value)
 	}
-	delete(controllers, controller.Spec.Id.Value)
+	if ids := s.namespacesVisibleTo(in.ControllerId.Value); len(ids) != 0 {
+		err := status.Errorf(codes.FailedPrecondition, "namespaces %v are visible to controller %s", ids, in.ControllerId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	subsys, ok := s.registry.subsystem(controller.Spec.SubsystemId.GetValue())
+	if !ok {
+		err := fmt.Errorf("unable to find subsystem %s", controller.Spec.SubsystemId.GetValue())
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	addr, err := controllerAddress(controller.Spec)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	params := NvmfSubsystemRemoveListenerParams{
+		Nqn:           subsys.Spec.Nqn,
+		ListenAddress: addr,
+	}
+	var result NvmfSubsystemRemoveListenerResult
+	err = s.registry.controllers.remove(ctx, controller.Spec.Id.Value, func() error {
+		return call(ctx, "nvmf_subsystem_remove_listener", &params, &result)
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	// the controller is gone already, a directory left behind is harmless
+	if err := os.RemoveAll(addr.Traddr); err != nil {
+		log.Printf("error: %v", err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
+// UpdateNVMeController changes what the bridge records only. Moving the
+// controller to another subsystem or PCIe function takes a new endpoint,
+// so it has to be deleted and created again.
 func (s *server) UpdateNVMeController(ctx context.Context, in *pb.UpdateNVMeControllerRequest) (*pb.NVMeController, error) {
 	log.Printf("Received from client: %v", in.Controller)
-	controllers[in.Controller.Spec.Id.Value] = in.Controller
+	controller, ok := s.registry.controller(in.Controller.Spec.Id.Value)
+	if !ok {
+		return nil, fmt.Errorf("error finding controller %s", in.Controller.Spec.Id.Value)
+	}
+	if !proto.Equal(controller.Spec.SubsystemId, in.Controller.Spec.SubsystemId) || !proto.Equal(controller.Spec.PcieId, in.Controller.Spec.PcieId) {
+		err := status.Errorf(codes.InvalidArgument, "cannot change the subsystem or PCIe ID of controller %s", in.Controller.Spec.Id.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	err := s.registry.controllers.put(ctx, in.Controller.Spec.Id.Value, in.Controller, nil)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
 	response := &pb.NVMeController{}
-	err := deepcopier.Copy(in.Controller).To(response)
+	err = deepcopier.Copy(in.Controller).To(response)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -177,32 +349,60 @@ func (s *server) UpdateNVMeController(ctx context.Context, in *pb.UpdateNVMeCont
 func (s *server) ListNVMeController(ctx context.Context, in *pb.ListNVMeControllerRequest) (*pb.ListNVMeControllerResponse, error) {
 	log.Printf("Received from client: %v", in.SubsystemId)
 	Blobarray := []*pb.NVMeController{}
-	for _, controller := range controllers {
-		Blobarray = append(Blobarray, controller)
+	for _, controller := range s.registry.controllers.values() {
+		Blobarray = append(Blobarray, controller.(*pb.NVMeController))
 	}
 	return &pb.ListNVMeControllerResponse{Controllers: Blobarray}, nil
 }
 
 func (s *server) GetNVMeController(ctx context.Context, in *pb.GetNVMeControllerRequest) (*pb.NVMeController, error) {
 	log.Printf("Received from client: %v", in.ControllerId)
-	controller, ok := controllers[in.ControllerId.Value]
+	controller, ok := s.registry.controller(in.ControllerId.Value)
 	if !ok {
 		return nil, fmt.Errorf("error finding controller %s", in.ControllerId.Value)
 	}
 	return &pb.NVMeController{Spec: &pb.NVMeControllerSpec{Id: in.ControllerId, NvmeControllerId: controller.Spec.NvmeControllerId}}, nil
 }
 
+// NVMeControllerStats counts the queue pairs on the controller's endpoint,
+// in JSON
 func (s *server) NVMeControllerStats(ctx context.Context, in *pb.NVMeControllerStatsRequest) (*pb.NVMeControllerStatsResponse, error) {
-	log.Printf("Received from client: %v", in.Id)
-	return &pb.NVMeControllerStatsResponse{}, nil
+	log.Printf("NVMeControllerStats: Received from client: %v", in)
+	controller, ok := s.registry.controller(in.Id.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find controller %s", in.Id.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	subsys, ok := s.registry.subsystem(controller.Spec.SubsystemId.GetValue())
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", controller.Spec.SubsystemId.GetValue())
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	addr, err := controllerAddress(controller.Spec)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	stats, err := getQpairStats(ctx, subsys.Spec.Nqn, &addr)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	encoded, err := marshalStats(stats)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return &pb.NVMeControllerStatsResponse{Id: in.Id, Stats: encoded}, nil
 }
 
 // ////////////////////////////////////////////////////////
-var namespaces = map[string]*pb.NVMeNamespace{}
 
 func (s *server) CreateNVMeNamespace(ctx context.Context, in *pb.CreateNVMeNamespaceRequest) (*pb.NVMeNamespace, error) {
 	log.Printf("CreateNVMeNamespace: Received from client: %v", in)
-	subsys, ok := subsystems[in.Namespace.Spec.SubsystemId.Value]
+	subsys, ok := s.registry.subsystem(in.Namespace.Spec.SubsystemId.Value)
 	if !ok {
 		err := fmt.Errorf("unable to find subsystem %s", in.Namespace.Spec.SubsystemId.Value)
 		log.Printf("error: %v", err)
@@ -217,18 +417,64 @@ func (s *server) CreateNVMeNamespace(ctx context.Context, in *pb.CreateNVMeNames
 
 	// TODO: using bdev for volume id as a middle end handle for now
 	params.Namespace.BdevName = in.Namespace.Spec.VolumeId.Value
+	params.Namespace.Nsid = int(in.Namespace.Spec.HostNsid)
+	params.Namespace.Nguid = in.Namespace.Spec.Nguid
+	params.Namespace.UUID = in.Namespace.Spec.GetUuid().GetValue()
+	if in.Namespace.Spec.Eui64 != 0 {
+		params.Namespace.Eui64 = fmt.Sprintf("%016X", uint64(in.Namespace.Spec.Eui64))
+	}
+	// a namespace for a controller is hidden from the other hosts
+	var hosts []string
+	if in.Namespace.Spec.ControllerId != nil {
+		var err error
+		hosts, err = s.visibleHosts(s.registry.visibility(in.Namespace), in.Namespace.Spec.SubsystemId.Value)
+		if err != nil {
+			log.Printf("error: %v", err)
+			return nil, err
+		}
+		params.Namespace.NoAutoVisible = true
+	}
 
+	// the NSID SPDK picks is saved, and a retry leaving it to SPDK again
+	// asks for the same namespace
+	namespace := proto.Clone(in.Namespace).(*pb.NVMeNamespace)
+	if old, ok := s.registry.namespace(namespace.Spec.Id.Value); ok && namespace.Spec.HostNsid == 0 {
+		namespace.Spec.HostNsid = old.Spec.HostNsid
+	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
 	var result NvmfSubsystemAddNsResult
-	err := call("nvmf_subsystem_add_ns", &params, &result)
+	existing, err := s.registry.namespaces.create(ctx, namespace.Spec.Id.Value, namespace, recreating(ctx), func() error {
+		err := tx.call("nvmf_subsystem_add_ns", &params, &result, func(ctx context.Context) error {
+			return call(ctx, "nvmf_subsystem_remove_ns", &NvmfSubsystemRemoveNsParams{Nqn: params.Nqn, Nsid: int(result)}, nil)
+		})
+		if err != nil {
+			return err
+		}
+		for _, host := range hosts {
+			// removing the namespace undoes these too
+			var hostResult NvmfNsAddHostResult
+			err := tx.call("nvmf_ns_add_host", &NvmfNsAddHostParams{Nqn: params.Nqn, Nsid: int(result), Host: host}, &hostResult, nil)
+			if err != nil {
+				return err
+			}
+		}
+		namespace.Spec.HostNsid = int32(result)
+		return nil
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("CreateNVMeNamespace: %s already exists", in.Namespace.Spec.Id.Value)
+		return existing.(*pb.NVMeNamespace), nil
+	}
 	log.Printf("Received from SPDK: %v", result)
-	namespaces[in.Namespace.Spec.Id.Value] = in.Namespace
 
 	response := &pb.NVMeNamespace{}
-	err = deepcopier.Copy(in.Namespace).To(response)
+	err = deepcopier.Copy(namespace).To(response)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -238,13 +484,13 @@ func (s *server) CreateNVMeNamespace(ctx context.Context, in *pb.CreateNVMeNames
 
 func (s *server) DeleteNVMeNamespace(ctx context.Context, in *pb.DeleteNVMeNamespaceRequest) (*emptypb.Empty, error) {
 	log.Printf("DeleteNVMeNamespace: Received from client: %v", in)
-	namespace, ok := namespaces[in.NamespaceId.Value]
+	namespace, ok := s.registry.namespace(in.NamespaceId.Value)
 	if !ok {
 		err := fmt.Errorf("unable to find key %s", in.NamespaceId.Value)
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	subsys, ok := subsystems[namespace.Spec.SubsystemId.Value]
+	subsys, ok := s.registry.subsystem(namespace.Spec.SubsystemId.Value)
 	if !ok {
 		err := fmt.Errorf("unable to find subsystem %s", namespace.Spec.SubsystemId.Value)
 		log.Printf("error: %v", err)
@@ -258,21 +504,34 @@ func (s *server) DeleteNVMeNamespace(ctx context.Context, in *pb.DeleteNVMeNames
 		Nsid: int(namespace.Spec.HostNsid),
 	}
 	var result NvmfSubsystemRemoveNsResult
-	err := call("nvmf_subsystem_remove_ns", &params, &result)
+	err := s.registry.namespaces.remove(ctx, namespace.Spec.Id.Value, func() error {
+		return call(ctx, "nvmf_subsystem_remove_ns", &params, &result)
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
 	log.Printf("Received from SPDK: %v", result)
-	delete(namespaces, namespace.Spec.Id.Value)
+	if err := s.registry.visibilities.remove(ctx, namespace.Spec.Id.Value, nil); err != nil {
+		log.Printf("error: %v", err)
+	}
 	return &emptypb.Empty{}, nil
 }
 
 func (s *server) UpdateNVMeNamespace(ctx context.Context, in *pb.UpdateNVMeNamespaceRequest) (*pb.NVMeNamespace, error) {
 	log.Printf("Received from client: %v", in.Namespace)
-	namespaces[in.Namespace.Spec.Id.Value] = in.Namespace
+	if old, ok := s.registry.namespace(in.Namespace.Spec.Id.Value); ok && !proto.Equal(old.Spec.ControllerId, in.Namespace.Spec.ControllerId) {
+		err := status.Errorf(codes.InvalidArgument, "cannot change the controller of namespace %s, update its visibility instead", in.Namespace.Spec.Id.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	err := s.registry.namespaces.put(ctx, in.Namespace.Spec.Id.Value, in.Namespace, nil)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
 	response := &pb.NVMeNamespace{}
-	err := deepcopier.Copy(in.Namespace).To(response)
+	err = deepcopier.Copy(in.Namespace).To(response)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -285,7 +544,7 @@ func (s *server) ListNVMeNamespace(ctx context.Context, in *pb.ListNVMeNamespace
 
 	nqn := ""
 	if in.SubsystemId != nil {
-		subsys, ok := subsystems[in.SubsystemId.Value]
+		subsys, ok := s.registry.subsystem(in.SubsystemId.Value)
 		if !ok {
 			err := fmt.Errorf("unable to find subsystem %s", in.SubsystemId.Value)
 			log.Printf("error: %v", err)
@@ -293,84 +552,184 @@ func (s *server) ListNVMeNamespace(ctx context.Context, in *pb.ListNVMeNamespace
 		}
 		nqn = subsys.Spec.Nqn
 	}
-	var result []NvmfGetSubsystemsResult
-	err := call("nvmf_get_subsystems", nil, &result)
+	join, err := s.joinNamespaces(ctx)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
 
 	Blobarray := []*pb.NVMeNamespace{}
-	for i := range result {
-		rr := &result[i]
+	for i := range join.subsystems {
+		rr := &join.subsystems[i]
 		if rr.Nqn == nqn || nqn == "" {
 			for j := range rr.Namespaces {
-				r := &rr.Namespaces[j]
-				Blobarray = append(Blobarray, &pb.NVMeNamespace{Spec: &pb.NVMeNamespaceSpec{HostNsid: int32(r.Nsid)}})
+				Blobarray = append(Blobarray, join.namespace(rr.Nqn, &rr.Namespaces[j]))
 			}
 		}
 	}
-	if len(Blobarray) > 0 {
-		return &pb.ListNVMeNamespaceResponse{Namespaces: Blobarray}, nil
-	}
-
-	msg := fmt.Sprintf("Could not find any namespaces for NQN: %s", nqn)
-	log.Print(msg)
-	return nil, status.Errorf(codes.InvalidArgument, msg)
+	return &pb.ListNVMeNamespaceResponse{Namespaces: Blobarray}, nil
 }
 
+// GetNVMeNamespace also finds namespaces created outside the bridge by the
+// IDs ListNVMeNamespace gives them
 func (s *server) GetNVMeNamespace(ctx context.Context, in *pb.GetNVMeNamespaceRequest) (*pb.NVMeNamespace, error) {
 	log.Printf("GetNVMeNamespace: Received from client: %v", in)
-	namespace, ok := namespaces[in.NamespaceId.Value]
-	if !ok {
-		err := fmt.Errorf("unable to find key %s", in.NamespaceId.Value)
-		log.Printf("error: %v", err)
-		return nil, err
+	var nqn string
+	var nsid int
+	if namespace, ok := s.registry.namespace(in.NamespaceId.Value); ok {
+		// fetch subsystems -> namespaces from server, match the nsid to find the corresponding namespace
+		subsys, ok := s.registry.subsystem(namespace.Spec.SubsystemId.Value)
+		if !ok {
+			err := fmt.Errorf("unable to find subsystem %s", namespace.Spec.SubsystemId.Value)
+			log.Printf("error: %v", err)
+			// TODO: temp workaround
+			subsys = &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{Nqn: namespace.Spec.SubsystemId.Value}}
+			// return nil, err
+		}
+		nqn, nsid = subsys.Spec.Nqn, int(namespace.Spec.HostNsid)
+	} else if i := strings.LastIndex(in.NamespaceId.Value, "/"); i > 0 {
+		nqn = in.NamespaceId.Value[:i]
+		nsid, _ = strconv.Atoi(in.NamespaceId.Value[i+1:])
 	}
-	// TODO: do we even query SPDK to confirm if namespace is present?
-	// return namespace, nil
-
-	// fetch subsystems -> namespaces from server, match the nsid to find the corresponding namespace
-	subsys, ok := subsystems[namespace.Spec.SubsystemId.Value]
-	if !ok {
-		err := fmt.Errorf("unable to find subsystem %s", namespace.Spec.SubsystemId.Value)
+	if nsid == 0 {
+		err := status.Errorf(codes.NotFound, "unable to find namespace %s", in.NamespaceId.Value)
 		log.Printf("error: %v", err)
-		// TODO: temp workaround
-		subsys = &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{Nqn: namespace.Spec.SubsystemId.Value}}
-		// return nil, err
+		return nil, err
 	}
 
-	var result []NvmfGetSubsystemsResult
-	err := call("nvmf_get_subsystems", nil, &result)
+	join, err := s.joinNamespaces(ctx)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	for i := range result {
-		rr := &result[i]
-		if rr.Nqn == subsys.Spec.Nqn {
+	for i := range join.subsystems {
+		rr := &join.subsystems[i]
+		if rr.Nqn == nqn {
 			for j := range rr.Namespaces {
 				r := &rr.Namespaces[j]
-				if int32(r.Nsid) == namespace.Spec.HostNsid {
-					return &pb.NVMeNamespace{Spec: &pb.NVMeNamespaceSpec{
-						Id: namespace.Spec.Id, HostNsid: namespace.Spec.HostNsid}}, nil
+				if r.Nsid == nsid {
+					return join.namespace(nqn, r), nil
 				}
 			}
-			msg := fmt.Sprintf("Could not find NSID: %d", namespace.Spec.HostNsid)
-			log.Print(msg)
-			return nil, status.Errorf(codes.InvalidArgument, msg)
+			err := status.Errorf(codes.NotFound, "unable to find NSID %d in subsystem %s", nsid, nqn)
+			log.Printf("error: %v", err)
+			return nil, err
 		}
 	}
-	msg := fmt.Sprintf("Could not find NQN: %s", subsys.Spec.Nqn)
-	log.Print(msg)
-	return nil, status.Errorf(codes.InvalidArgument, msg)
+	err = status.Errorf(codes.NotFound, "unable to find subsystem %s", nqn)
+	log.Printf("error: %v", err)
+	return nil, err
 }
 
+// namespaceJoin is what SPDK and the bridge know about namespaces, to
+// complete the namespaces SPDK reports
+type namespaceJoin struct {
+	subsystems   []NvmfGetSubsystemsResult
+	bdevs        map[string]*BdevGetBdevsResult
+	subsystemIDs map[string]string            // NQN -> subsystem ID
+	objects      map[string]*pb.NVMeNamespace // subsystem ID/NSID -> namespace
+}
+
+func (s *server) joinNamespaces(ctx context.Context) (*namespaceJoin, error) {
+	join := &namespaceJoin{
+		bdevs:        map[string]*BdevGetBdevsResult{},
+		subsystemIDs: map[string]string{},
+		objects:      map[string]*pb.NVMeNamespace{},
+	}
+	if err := call(ctx, "nvmf_get_subsystems", nil, &join.subsystems); err != nil {
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", join.subsystems)
+	var bdevs []BdevGetBdevsResult
+	if err := call(ctx, "bdev_get_bdevs", nil, &bdevs); err != nil {
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", bdevs)
+	for i := range bdevs {
+		join.bdevs[bdevs[i].Name] = &bdevs[i]
+	}
+	for _, m := range s.registry.subsystems.values() {
+		subsys := m.(*pb.NVMeSubsystem)
+		join.subsystemIDs[subsys.Spec.Nqn] = subsys.Spec.Id.Value
+	}
+	for _, m := range s.registry.namespaces.values() {
+		namespace := m.(*pb.NVMeNamespace)
+		join.objects[fmt.Sprintf("%s/%d", namespace.Spec.SubsystemId.Value, namespace.Spec.HostNsid)] = namespace
+	}
+	return join, nil
+}
+
+// namespace completes namespace r of subsystem nqn with the bridge's object
+// and the bdev behind it. A namespace created outside the bridge gets the ID
+// nqn/nsid, and its subsystem the NQN as ID if that is unknown as well.
+func (j *namespaceJoin) namespace(nqn string, r *NvmfSubsystemNamespace) *pb.NVMeNamespace {
+	subsysID, ok := j.subsystemIDs[nqn]
+	if !ok {
+		subsysID = nqn
+	}
+	spec := &pb.NVMeNamespaceSpec{
+		Id:          &pc.ObjectKey{Value: fmt.Sprintf("%s/%d", nqn, r.Nsid)},
+		SubsystemId: &pc.ObjectKey{Value: subsysID},
+	}
+	if namespace, ok := j.objects[fmt.Sprintf("%s/%d", subsysID, r.Nsid)]; ok {
+		spec = namespace.Spec
+	}
+	reported := namespaceSpec(r)
+	spec.HostNsid = reported.HostNsid
+	spec.Nguid = reported.Nguid
+	spec.Eui64 = reported.Eui64
+	spec.Uuid = reported.Uuid
+	name := r.BdevName
+	if name == "" {
+		name = r.Name
+	}
+	spec.VolumeId = &pc.ObjectKey{Value: name}
+	if bdev, ok := j.bdevs[name]; ok {
+		spec.BlockSize = bdev.BlockSize
+		spec.BlocksCount = bdev.NumBlocks
+	}
+	return &pb.NVMeNamespace{Spec: spec, Status: &pb.NVMeNamespaceStatus{
+		PciState:     pb.NVMeNamespacePciState_NVME_NAMESPACE_PCI_STATE_ENABLED,
+		PciOperState: pb.NVMeNamespacePciOperState_NVME_NAMESPACE_PCI_OPER_STATE_ONLINE,
+	}}
+}
+
+// namespaceSpec reports the identifiers of a namespace as SPDK has them
+func namespaceSpec(r *NvmfSubsystemNamespace) *pb.NVMeNamespaceSpec {
+	spec := &pb.NVMeNamespaceSpec{
+		HostNsid: int32(r.Nsid),
+		Nguid:    r.Nguid,
+	}
+	if eui64, err := strconv.ParseUint(r.Eui64, 16, 64); err == nil {
+		spec.Eui64 = int64(eui64)
+	}
+	if r.UUID != "" {
+		spec.Uuid = &pc.Uuid{Value: r.UUID}
+	}
+	return spec
+}
+
+// NVMeNamespaceStats returns the I/O counters of the namespace's bdev, in
+// JSON
 func (s *server) NVMeNamespaceStats(ctx context.Context, in *pb.NVMeNamespaceStatsRequest) (*pb.NVMeNamespaceStatsResponse, error) {
-	log.Printf("Received from client: %v", in.NamespaceId)
-	return &pb.NVMeNamespaceStatsResponse{}, nil
+	log.Printf("NVMeNamespaceStats: Received from client: %v", in)
+	namespace, ok := s.registry.namespace(in.NamespaceId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find namespace %s", in.NamespaceId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	stats, err := getVolumeStats(ctx, namespace.Spec.GetVolumeId().GetValue(), nil)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	encoded, err := encodeVolumeStats(stats)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return &pb.NVMeNamespaceStatsResponse{Id: in.NamespaceId, Stats: encoded}, nil
 }
 
 //////////////////////////////////////////////////////////
@@ -381,12 +740,22 @@ func (s *server) CreateVirtioBlk(ctx context.Context, in *pb.CreateVirtioBlkRequ
 		Ctrlr:   in.Controller.Id.Value,
 		DevName: in.Controller.VolumeId.Value,
 	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
 	var result VhostCreateBlkControllerResult
-	err := call("vhost_create_blk_controller", &params, &result)
+	existing, err := s.registry.virtioBlks.create(ctx, in.Controller.Id.Value, in.Controller, recreating(ctx), func() error {
+		return tx.call("vhost_create_blk_controller", &params, &result,
+			undoCall("vhost_delete_controller", &VhostDeleteControllerParams{Ctrlr: params.Ctrlr}))
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("CreateVirtioBlk: %s already exists", in.Controller.Id.Value)
+		return &pb.VirtioBlk{}, nil
+	}
 	log.Printf("Received from SPDK: %v", result)
 	if !result {
 		log.Printf("Could not create: %v", in)
@@ -400,7 +769,9 @@ func (s *server) DeleteVirtioBlk(ctx context.Context, in *pb.DeleteVirtioBlkRequ
 		Ctrlr: in.GetControllerId().GetValue(),
 	}
 	var result VhostDeleteControllerResult
-	err := call("vhost_delete_controller", &params, &result)
+	err := s.registry.virtioBlks.remove(ctx, in.GetControllerId().GetValue(), func() error {
+		return call(ctx, "vhost_delete_controller", &params, &result)
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -420,7 +791,7 @@ func (s *server) UpdateVirtioBlk(ctx context.Context, in *pb.UpdateVirtioBlkRequ
 func (s *server) ListVirtioBlk(ctx context.Context, in *pb.ListVirtioBlkRequest) (*pb.ListVirtioBlkResponse, error) {
 	log.Printf("ListVirtioBlk: Received from client: %v", in)
 	var result []VhostGetControllersResult
-	err := call("vhost_get_controllers", nil, &result)
+	err := call(ctx, "vhost_get_controllers", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -440,7 +811,7 @@ func (s *server) GetVirtioBlk(ctx context.Context, in *pb.GetVirtioBlkRequest) (
 		Name: in.GetControllerId().GetValue(),
 	}
 	var result []VhostGetControllersResult
-	err := call("vhost_get_controllers", &params, &result)
+	err := call(ctx, "vhost_get_controllers", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -455,8 +826,33 @@ func (s *server) GetVirtioBlk(ctx context.Context, in *pb.GetVirtioBlkRequest) (
 }
 
 func (s *server) VirtioBlkStats(ctx context.Context, in *pb.VirtioBlkStatsRequest) (*pb.VirtioBlkStatsResponse, error) {
-	log.Printf("Received from client: %v", in)
-	return &pb.VirtioBlkStatsResponse{}, nil
+	log.Printf("VirtioBlkStats: Received from client: %v", in)
+	params := VhostGetControllersParams{
+		Name: in.GetControllerId().GetValue(),
+	}
+	var result []VhostGetControllersResult
+	err := call(ctx, "vhost_get_controllers", &params, &result)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	if len(result) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result))
+		log.Print(msg)
+		return nil, status.Errorf(codes.InvalidArgument, msg)
+	}
+	stats, err := getVolumeStats(ctx, result[0].BackendSpecific.Block.Bdev, nil)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	encoded, err := encodeVolumeStats(stats)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return &pb.VirtioBlkStatsResponse{Id: in.ControllerId, Stats: encoded}, nil
 }
 
 //////////////////////////////////////////////////////////
@@ -467,7 +863,7 @@ func (s *server) CreateVirtioScsiController(ctx context.Context, in *pb.CreateVi
 		Ctrlr: in.GetController().GetId().GetValue(),
 	}
 	var result VhostCreateScsiControllerResult
-	err := call("vhost_create_scsi_controller", &params, &result)
+	err := call(ctx, "vhost_create_scsi_controller", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -485,7 +881,7 @@ func (s *server) DeleteVirtioScsiController(ctx context.Context, in *pb.DeleteVi
 		Ctrlr: in.GetControllerId().GetValue(),
 	}
 	var result VhostDeleteControllerResult
-	err := call("vhost_delete_controller", &params, &result)
+	err := call(ctx, "vhost_delete_controller", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -505,7 +901,7 @@ func (s *server) UpdateVirtioScsiController(ctx context.Context, in *pb.UpdateVi
 func (s *server) ListVirtioScsiController(ctx context.Context, in *pb.ListVirtioScsiControllerRequest) (*pb.ListVirtioScsiControllerResponse, error) {
 	log.Printf("ListVirtioScsiController: Received from client: %v", in)
 	var result []VhostGetControllersResult
-	err := call("vhost_get_controllers", nil, &result)
+	err := call(ctx, "vhost_get_controllers", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -525,7 +921,7 @@ func (s *server) GetVirtioScsiController(ctx context.Context, in *pb.GetVirtioSc
 		Name: in.GetControllerId().GetValue(),
 	}
 	var result []VhostGetControllersResult
-	err := call("vhost_get_controllers", &params, &result)
+	err := call(ctx, "vhost_get_controllers", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -558,7 +954,7 @@ func (s *server) CreateVirtioScsiLun(ctx context.Context, in *pb.CreateVirtioScs
 		Bdev: in.Lun.VolumeId.Value,
 	}
 	var result int
-	err := call("vhost_scsi_controller_add_target", &params, &result)
+	err := call(ctx, "vhost_scsi_controller_add_target", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -577,7 +973,7 @@ func (s *server) DeleteVirtioScsiLun(ctx context.Context, in *pb.DeleteVirtioScs
 		Num:  5,
 	}
 	var result bool
-	err := call("vhost_scsi_controller_remove_target", &params, &result)
+	err := call(ctx, "vhost_scsi_controller_remove_target", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -597,7 +993,7 @@ func (s *server) UpdateVirtioScsiLun(ctx context.Context, in *pb.UpdateVirtioScs
 func (s *server) ListVirtioScsiLun(ctx context.Context, in *pb.ListVirtioScsiLunRequest) (*pb.ListVirtioScsiLunResponse, error) {
 	log.Printf("ListVirtioScsiLun: Received from client: %v", in)
 	var result []VhostGetControllersResult
-	err := call("vhost_get_controllers", nil, &result)
+	err := call(ctx, "vhost_get_controllers", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -617,7 +1013,7 @@ func (s *server) GetVirtioScsiLun(ctx context.Context, in *pb.GetVirtioScsiLunRe
 		Name: in.GetControllerId().GetValue(),
 	}
 	var result []VhostGetControllersResult
-	err := call("vhost_get_controllers", &params, &result)
+	err := call(ctx, "vhost_get_controllers", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
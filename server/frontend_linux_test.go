@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+//go:build linux
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"syscall"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkSent fails unless the calls of method had the parameters want, in
+// JSON
+func checkSent(t *testing.T, spdk *fakeSpdk, method string, want ...string) {
+	t.Helper()
+	sent := spdk.sent(method)
+	if len(sent) != len(want) {
+		t.Errorf("expected %d calls of %s, got %d", len(want), method, len(sent))
+		return
+	}
+	for i := range want {
+		var got, expected interface{}
+		if err := json.Unmarshal(sent[i], &got); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(want[i]), &expected); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("expected %s with %s, got %s", method, want[i], sent[i])
+		}
+	}
+}
+
+// TestFrontEnd_NVMeControllerEndpoint checks what the bridge itself does
+// for the vfio-user endpoint of a controller: the directory it creates for
+// SPDK's socket, the SPDK calls listening on it, and the cleanup on
+// delete. The socket in the directory is SPDK's own and is not checked,
+// the fake SPDK only stands in for it.
+func TestFrontEnd_NVMeControllerEndpoint(t *testing.T) {
+	defer syscall.Umask(syscall.Umask(0o022))
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestController(t, c)
+	ctx := context.Background()
+
+	dir := filepath.Join(*vfiouserDir, "port0-pf1-vf0")
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.IsDir() || info.Mode().Perm() != 0o755 {
+		t.Errorf("expected a directory %s with permissions 0755, got %v", dir, info.Mode())
+	}
+	checkSent(t, spdk, "nvmf_create_transport", `{"trtype": "VFIOUSER"}`)
+	listener := `{"nqn": "` + testNqn + `", "listen_address": {"trtype": "VFIOUSER", "traddr": "` + dir + `", "trsvcid": "0"}}`
+	checkSent(t, spdk, "nvmf_subsystem_add_listener", listener)
+
+	// a controller SPDK still has keeps its directory
+	spdk.failNext("nvmf_subsystem_remove_listener", fakeInvalidParams, "failed")
+	if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("expected %s kept, got %v", dir, err)
+	}
+
+	if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	checkSent(t, spdk, "nvmf_subsystem_remove_listener", listener, listener)
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected %s removed, got %v", dir, err)
+	}
+	// the transport stays for the next controller
+	createTestController(t, c)
+	checkSent(t, spdk, "nvmf_create_transport", `{"trtype": "VFIOUSER"}`)
+}
@@ -1,29 +1,897 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	bridge "opi.storage.v1/proto/gen/go"
 )
 
-func TestFrontEnd_CreateNVMeSubsystem(t *testing.T) {
+const testNqn = "nqn.2022-09.io.spdk:opi1"
+
+func testSubsystem() *pb.NVMeSubsystem {
+	return &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{
+		Id:            &pc.ObjectKey{Value: "subsystem-test"},
+		Nqn:           testNqn,
+		SerialNumber:  "OPI00000000000000001",
+		ModelNumber:   "OPI SPDK bridge",
+		MaxNamespaces: 16,
+	}}
+}
 
+func testNamespace() *pb.NVMeNamespace {
+	return &pb.NVMeNamespace{Spec: &pb.NVMeNamespaceSpec{
+		Id:          &pc.ObjectKey{Value: "namespace-test"},
+		SubsystemId: &pc.ObjectKey{Value: "subsystem-test"},
+		HostNsid:    1,
+		VolumeId:    &pc.ObjectKey{Value: "Malloc1"},
+	}}
+}
+
+func createTestSubsystem(t *testing.T, c *bridgeClients) {
+	_, err := c.nvme.CreateNVMeSubsystem(context.Background(), &pb.CreateNVMeSubsystemRequest{Subsystem: testSubsystem()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createTestNamespace(t *testing.T, c *bridgeClients) {
+	createTestSubsystem(t, c)
+	_, err := c.nvme.CreateNVMeNamespace(context.Background(), &pb.CreateNVMeNamespaceRequest{Namespace: testNamespace()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFrontEnd_CreateNVMeSubsystem(t *testing.T) {
+	tests := map[string]struct {
+		spdkErr int
+		code    codes.Code
+	}{
+		"valid request":   {0, codes.OK},
+		"already exists":  {fakeEEXIST, codes.AlreadyExists},
+		"invalid request": {fakeInvalidParams, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			if tt.spdkErr != 0 {
+				spdk.setError("nvmf_create_subsystem", tt.spdkErr, "failed")
+			}
+			response, err := c.nvme.CreateNVMeSubsystem(context.Background(), &pb.CreateNVMeSubsystemRequest{Subsystem: testSubsystem()})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if err == nil && response.Spec.Nqn != testNqn {
+				t.Errorf("unexpected response %v", response)
+			}
+			if _, ok := c.server.registry.subsystem("subsystem-test"); ok != (err == nil) {
+				t.Errorf("subsystem recorded %v on error %v", ok, err)
+			}
+			if err == nil {
+				spdk.mu.Lock()
+				s := spdk.subsystems[testNqn]
+				spdk.mu.Unlock()
+				if s.serialNumber != "OPI00000000000000001" || s.modelNumber != "OPI SPDK bridge" || s.maxNamespaces != 16 {
+					t.Errorf("spec not passed to SPDK: %+v", s)
+				}
+			}
+		})
+	}
 }
 
 func TestFrontEnd_DeleteNVMeSubsystem(t *testing.T) {
+	tests := map[string]struct {
+		create  bool
+		spdkErr int
+		code    codes.Code
+	}{
+		"valid request":   {true, 0, codes.OK},
+		"unknown id":      {false, 0, codes.Unknown},
+		"spdk busy":       {true, -16, codes.FailedPrecondition},
+		"spdk has no nqn": {true, fakeInvalidParams, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			if tt.create {
+				createTestSubsystem(t, c)
+			}
+			if tt.spdkErr != 0 {
+				spdk.setError("nvmf_delete_subsystem", tt.spdkErr, "failed")
+			}
+			_, err := c.nvme.DeleteNVMeSubsystem(context.Background(), &pb.DeleteNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if left := c.server.registry.subsystems.values(); err == nil && len(left) != 0 {
+				t.Errorf("subsystem still recorded: %v", left)
+			}
+		})
+	}
+}
+
+func TestFrontEnd_DeleteNVMeSubsystemChildren(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestNamespace(t, c)
+	createTestTransport(t, c)
+	createTestListener(t, c)
+	createTestHost(t, c)
+	ctx := context.Background()
+	id := &pc.ObjectKey{Value: "subsystem-test"}
+
+	_, err := c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: id})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if n := spdk.called("nvmf_delete_subsystem"); n != 0 {
+		t.Errorf("expected the subsystem kept in SPDK, got %d deletes", n)
+	}
 
+	if _, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.listener.DeleteNVMfListener(ctx, &bridge.DeleteNVMfListenerRequest{ListenerId: &pc.ObjectKey{Value: "listener-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.host.DeleteNVMfHost(ctx, &bridge.DeleteNVMfHostRequest{HostId: &pc.ObjectKey{Value: "host-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: id}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a namespace of the recreated subsystem is added to SPDK again
+	createTestNamespace(t, c)
+	if n := spdk.called("nvmf_subsystem_add_ns"); n != 2 {
+		t.Errorf("expected the namespace added twice, got %d", n)
+	}
 }
 
 func TestFrontEnd_UpdateNVMeSubsystem(t *testing.T) {
-
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	subsys := testSubsystem()
+	subsys.Spec.ModelNumber = "OPI Model"
+	response, err := c.nvme.UpdateNVMeSubsystem(context.Background(), &pb.UpdateNVMeSubsystemRequest{Subsystem: subsys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Spec.ModelNumber != "OPI Model" {
+		t.Errorf("unexpected response %v", response)
+	}
 }
 
 func TestFrontEnd_ListNVMeSubsystem(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	response, err := c.nvme.ListNVMeSubsystem(context.Background(), &pb.ListNVMeSubsystemRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs := map[string]*pb.NVMeSubsystemSpec{}
+	for _, s := range response.Subsystems {
+		specs[s.Spec.Nqn] = s.Spec
+	}
+	if specs["nqn.2014-08.org.nvmexpress.discovery"] == nil {
+		t.Errorf("unexpected subsystems %v", response.Subsystems)
+	}
+	if spec := specs[testNqn]; spec == nil || spec.SerialNumber != "OPI00000000000000001" || spec.ModelNumber != "OPI SPDK bridge" || spec.MaxNamespaces != 16 {
+		t.Errorf("unexpected subsystem %v", spec)
+	}
 
+	spdk.setError("nvmf_get_subsystems", -32603, "failed")
+	_, err = c.nvme.ListNVMeSubsystem(context.Background(), &pb.ListNVMeSubsystemRequest{})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal, got %v", err)
+	}
 }
 
 func TestFrontEnd_GetNVMeSubsystem(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	response, err := c.nvme.GetNVMeSubsystem(context.Background(), &pb.GetNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(response.Spec, testSubsystem().Spec) {
+		t.Errorf("expected %v, got %v", testSubsystem().Spec, response.Spec)
+	}
+
+	_, err = c.nvme.GetNVMeSubsystem(context.Background(), &pb.GetNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "unknown"}})
+	if err == nil {
+		t.Error("expected error for unknown subsystem")
+	}
+
+	// subsystem removed behind the bridge's back
+	spdk.mu.Lock()
+	delete(spdk.subsystems, testNqn)
+	spdk.mu.Unlock()
+	_, err = c.nvme.GetNVMeSubsystem(context.Background(), &pb.GetNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
 
+// connectTestQpairs connects a host to the test controller, with an admin
+// and an I/O queue pair, and another one over TCP with an admin queue pair
+func connectTestQpairs(spdk *fakeSpdk) {
+	vfiouser := NvmfListenAddress{Trtype: "VFIOUSER", Traddr: filepath.Join(*vfiouserDir, "port0-pf1-vf0"), Trsvcid: "0"}
+	tcp := NvmfListenAddress{Trtype: "TCP", Adrfam: "IPv4", Traddr: "127.0.0.1", Trsvcid: "4420"}
+	spdk.mu.Lock()
+	defer spdk.mu.Unlock()
+	spdk.subsystems[testNqn].qpairs = []NvmfSubsystemGetQpairsResult{
+		{Cntlid: 1, Qid: 0, State: "active", ListenAddress: vfiouser},
+		{Cntlid: 1, Qid: 1, State: "active", ListenAddress: vfiouser},
+		{Cntlid: 2, Qid: 0, State: "active", ListenAddress: tcp},
+	}
 }
 
 func TestFrontEnd_NVMeSubsystemStats(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	connectTestQpairs(spdk)
+	ctx := context.Background()
+	response, err := c.nvme.NVMeSubsystemStats(ctx, &pb.NVMeSubsystemStatsRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stats qpairStats
+	if err := json.Unmarshal([]byte(response.Stats), &stats); err != nil {
+		t.Fatal(err)
+	}
+	want := qpairStats{TickRate: 2000000000, AdminQpairs: 2, IoQpairs: 1, PollGroups: []pollGroupStats{{
+		Name: "nvmf_tgt_poll_group_0", AdminQpairs: 2, IoQpairs: 1, CurrentAdminQpairs: 2, CurrentIoQpairs: 1,
+	}}}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("expected %+v, got %+v", want, stats)
+	}
+
+	_, err = c.nvme.NVMeSubsystemStats(ctx, &pb.NVMeSubsystemStatsRequest{SubsystemId: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	spdk.setError("nvmf_get_stats", -32603, "failed")
+	_, err = c.nvme.NVMeSubsystemStats(ctx, &pb.NVMeSubsystemStatsRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected Internal, got %v", err)
+	}
+}
+
+func TestFrontEnd_NVMeControllerStats(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestController(t, c)
+	connectTestQpairs(spdk)
+	ctx := context.Background()
+	response, err := c.nvme.NVMeControllerStats(ctx, &pb.NVMeControllerStatsRequest{Id: &pc.ObjectKey{Value: "controller-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stats qpairStats
+	if err := json.Unmarshal([]byte(response.Stats), &stats); err != nil {
+		t.Fatal(err)
+	}
+	// the poll groups count the TCP queue pair too
+	if stats.AdminQpairs != 1 || stats.IoQpairs != 1 || len(stats.PollGroups) != 1 || stats.PollGroups[0].CurrentAdminQpairs != 2 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	_, err = c.nvme.NVMeControllerStats(ctx, &pb.NVMeControllerStatsRequest{Id: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func testController() *pb.NVMeController {
+	return &pb.NVMeController{Spec: &pb.NVMeControllerSpec{
+		Id:               &pc.ObjectKey{Value: "controller-test"},
+		SubsystemId:      &pc.ObjectKey{Value: "subsystem-test"},
+		PcieId:           &pb.PciEndpoint{PortId: 0, PhysicalFunction: 1, VirtualFunction: 0},
+		NvmeControllerId: 17,
+	}}
+}
+
+func createTestController(t *testing.T, c *bridgeClients) {
+	_, err := c.nvme.CreateNVMeController(context.Background(), &pb.CreateNVMeControllerRequest{Controller: testController()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFrontEnd_CreateNVMeController(t *testing.T) {
+	tests := map[string]struct {
+		change  func(c *pb.NVMeController)
+		spdkErr int
+		// the test controller of another subsystem has the PCIe function
+		taken bool
+		code  codes.Code
+	}{
+		"valid request":      {func(c *pb.NVMeController) {}, 0, false, codes.OK},
+		"unknown subsystem":  {func(c *pb.NVMeController) { c.Spec.SubsystemId.Value = "unknown" }, 0, false, codes.NotFound},
+		"missing PCIe ID":    {func(c *pb.NVMeController) { c.Spec.PcieId = nil }, 0, false, codes.InvalidArgument},
+		"invalid parameters": {func(c *pb.NVMeController) {}, fakeInvalidParams, false, codes.InvalidArgument},
+		"endpoint taken": {func(c *pb.NVMeController) {
+			c.Spec.Id.Value = "controller-other"
+			c.Spec.SubsystemId.Value = "subsystem-other"
+		}, 0, true, codes.AlreadyExists},
+		"endpoint taken in the subsystem": {func(c *pb.NVMeController) { c.Spec.Id.Value = "controller-other" }, 0, true, codes.AlreadyExists},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			ctx := context.Background()
+			createTestSubsystem(t, c)
+			if tt.taken {
+				other := &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{
+					Id:  &pc.ObjectKey{Value: "subsystem-other"},
+					Nqn: "nqn.2022-09.io.spdk:opi-other",
+				}}
+				if _, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: other}); err != nil {
+					t.Fatal(err)
+				}
+				createTestController(t, c)
+			}
+			if tt.spdkErr != 0 {
+				spdk.setError("nvmf_subsystem_add_listener", tt.spdkErr, "failed")
+			}
+			controller := testController()
+			tt.change(controller)
+			response, err := c.nvme.CreateNVMeController(ctx, &pb.CreateNVMeControllerRequest{Controller: controller})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if err == nil && !proto.Equal(response, controller) {
+				t.Errorf("expected %v, got %v", controller, response)
+			}
+			if _, ok := c.server.registry.controller(controller.Spec.Id.Value); ok != (err == nil) {
+				t.Errorf("controller recorded %v on error %v", ok, err)
+			}
+		})
+	}
+}
+
+// of two controllers created on the same PCIe function at once, one gets it
+func TestFrontEnd_CreateNVMeControllerRace(t *testing.T) {
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	errs := make(chan error, 2)
+	for _, id := range []string{"controller-a", "controller-b"} {
+		controller := testController()
+		controller.Spec.Id.Value = id
+		go func() {
+			_, err := c.nvme.CreateNVMeController(context.Background(), &pb.CreateNVMeControllerRequest{Controller: controller})
+			errs <- err
+		}()
+	}
+	got := map[codes.Code]int{}
+	for i := 0; i < 2; i++ {
+		got[status.Code(<-errs)]++
+	}
+	if got[codes.OK] != 1 || got[codes.AlreadyExists] != 1 {
+		t.Errorf("expected one OK and one AlreadyExists, got %v", got)
+	}
+	if n := len(c.server.registry.controllers.values()); n != 1 {
+		t.Errorf("expected one controller recorded, got %d", n)
+	}
+}
+
+func TestFrontEnd_NVMeController(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	ctx := context.Background()
+	controller := testController()
+
+	if _, err := c.nvme.CreateNVMeController(ctx, &pb.CreateNVMeControllerRequest{Controller: controller}); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(*vfiouserDir, "port0-pf1-vf0")
+	want := NvmfListenAddress{Trtype: "VFIOUSER", Traddr: dir, Trsvcid: "0"}
+	spdk.mu.Lock()
+	_, transport := spdk.transports["VFIOUSER"]
+	listening := spdk.subsystems[testNqn].listener(want) >= 0
+	spdk.mu.Unlock()
+	if !transport || !listening {
+		t.Errorf("expected a vfio-user listener on %s, transport %v, listener %v", dir, transport, listening)
+	}
+
+	controller.Spec.MaxNamespaces = 8
+	if _, err := c.nvme.UpdateNVMeController(ctx, &pb.UpdateNVMeControllerRequest{Controller: controller}); err != nil {
+		t.Fatal(err)
+	}
+	moved := proto.Clone(controller).(*pb.NVMeController)
+	moved.Spec.PcieId.PhysicalFunction = 2
+	if _, err := c.nvme.UpdateNVMeController(ctx, &pb.UpdateNVMeControllerRequest{Controller: moved}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+	list, err := c.nvme.ListNVMeController(ctx, &pb.ListNVMeControllerRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Controllers) != 1 || list.Controllers[0].Spec.MaxNamespaces != 8 {
+		t.Errorf("unexpected controllers %v", list.Controllers)
+	}
+	get, err := c.nvme.GetNVMeController(ctx, &pb.GetNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Spec.NvmeControllerId != 17 {
+		t.Errorf("unexpected controller %v", get)
+	}
+	if _, err := c.nvme.NVMeControllerStats(ctx, &pb.NVMeControllerStatsRequest{Id: &pc.ObjectKey{Value: "controller-test"}}); err != nil {
+		t.Error(err)
+	}
+
+	spdk.failNext("nvmf_subsystem_remove_listener", fakeInvalidParams, "failed")
+	if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if _, ok := c.server.registry.controller("controller-test"); !ok {
+		t.Error("controller forgotten although SPDK still listens")
+	}
+	if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	spdk.mu.Lock()
+	left := len(spdk.subsystems[testNqn].listeners)
+	spdk.mu.Unlock()
+	if left != 0 {
+		t.Error("listener left in SPDK")
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected %s removed, got %v", dir, err)
+	}
+	if _, err := c.nvme.GetNVMeController(ctx, &pb.GetNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); err == nil {
+		t.Error("expected error for deleted controller")
+	}
+	if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); err == nil {
+		t.Error("expected error for deleted controller")
+	}
+}
+
+func TestFrontEnd_CreateNVMeNamespace(t *testing.T) {
+	tests := map[string]struct {
+		volume string
+		code   codes.Code
+	}{
+		"valid request":  {"Malloc1", codes.OK},
+		"missing volume": {"Malloc9", codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			createTestSubsystem(t, c)
+			namespace := testNamespace()
+			namespace.Spec.VolumeId.Value = tt.volume
+			_, err := c.nvme.CreateNVMeNamespace(context.Background(), &pb.CreateNVMeNamespaceRequest{Namespace: namespace})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			spdk.mu.Lock()
+			defer spdk.mu.Unlock()
+			bridge := len(c.server.registry.namespaces.values())
+			if n := len(spdk.subsystems[testNqn].namespaces); n != bridge {
+				t.Errorf("bridge has %d namespaces, SPDK %d", bridge, n)
+			}
+		})
+	}
+}
+
+func TestFrontEnd_CreateNVMeNamespaceIdentifiers(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	ctx := context.Background()
+	namespace := testNamespace()
+	namespace.Spec.HostNsid = 5
+	namespace.Spec.Nguid = "0123456789ABCDEF0123456789ABCDEF"
+	namespace.Spec.Eui64 = 0x0123456789ABCDEF
+	namespace.Spec.Uuid = &pc.Uuid{Value: "6d2b5f1e-4c3a-4b8e-9f7d-1a2b3c4d5e6f"}
+	if _, err := c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: namespace}); err != nil {
+		t.Fatal(err)
+	}
+	spdk.mu.Lock()
+	got := spdk.subsystems[testNqn].namespaces[0]
+	spdk.mu.Unlock()
+	want := fakeNamespace{nsid: 5, bdev: "Malloc1", nguid: namespace.Spec.Nguid, eui64: "0123456789ABCDEF", uuid: namespace.Spec.Uuid.Value}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %+v in SPDK, got %+v", want, got)
+	}
+
+	response, err := c.nvme.GetNVMeNamespace(ctx, &pb.GetNVMeNamespaceRequest{NamespaceId: namespace.Spec.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Spec.HostNsid != 5 || response.Spec.Nguid != namespace.Spec.Nguid ||
+		response.Spec.Eui64 != namespace.Spec.Eui64 || !proto.Equal(response.Spec.Uuid, namespace.Spec.Uuid) {
+		t.Errorf("unexpected namespace %v", response.Spec)
+	}
+
+	namespace.Spec.Id.Value = "namespace-invalid"
+	namespace.Spec.HostNsid = 0
+	namespace.Spec.Nguid = "0123"
+	_, err = c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: namespace})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+// without a NSID the one SPDK assigns is kept, and a retry without one
+// still finds the namespace
+func TestFrontEnd_CreateNVMeNamespaceAssignedNsid(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	ctx := context.Background()
+	params := NvmfSubsystemAddNsParams{Nqn: testNqn}
+	params.Namespace.BdevName = "Malloc0"
+	if err := call(ctx, "nvmf_subsystem_add_ns", &params, nil); err != nil {
+		t.Fatal(err)
+	}
+	namespace := testNamespace()
+	namespace.Spec.HostNsid = 0
+	for i := 0; i < 2; i++ {
+		response, err := c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: namespace})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.Spec.HostNsid != 2 {
+			t.Errorf("expected NSID 2, got %v", response.Spec)
+		}
+	}
+	if stored, _ := c.server.registry.namespace("namespace-test"); stored.Spec.HostNsid != 2 {
+		t.Errorf("expected NSID 2 stored, got %v", stored.Spec)
+	}
+	if spdk.called("nvmf_subsystem_add_ns") != 2 {
+		t.Errorf("expected the retry answered by the bridge")
+	}
+	if _, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: namespace.Spec.Id}); err != nil {
+		t.Fatal(err)
+	}
+	spdk.mu.Lock()
+	left := spdk.subsystems[testNqn].namespaces
+	spdk.mu.Unlock()
+	if len(left) != 1 || left[0].nsid != 1 {
+		t.Errorf("expected NSID 1 left alone, got %+v", left)
+	}
+}
+
+func TestFrontEnd_DeleteNVMeNamespace(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestNamespace(t, c)
+	ctx := context.Background()
+
+	spdk.setError("nvmf_subsystem_remove_ns", -16, "busy")
+	_, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition, got %v", err)
+	}
+	spdk.clearError("nvmf_subsystem_remove_ns")
+
+	if _, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	spdk.mu.Lock()
+	left := len(spdk.subsystems[testNqn].namespaces)
+	spdk.mu.Unlock()
+	if len(c.server.registry.namespaces.values()) != 0 || left != 0 {
+		t.Error("namespace not deleted")
+	}
+	if _, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}}); err == nil {
+		t.Error("expected error for deleted namespace")
+	}
+}
+
+func TestFrontEnd_UpdateNVMeNamespace(t *testing.T) {
+	_, c := startBridge(t)
+	createTestNamespace(t, c)
+	namespace := testNamespace()
+	namespace.Spec.BlockSize = 4096
+	response, err := c.nvme.UpdateNVMeNamespace(context.Background(), &pb.UpdateNVMeNamespaceRequest{Namespace: namespace})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Spec.BlockSize != 4096 {
+		t.Errorf("unexpected response %v", response)
+	}
+}
+
+func TestFrontEnd_ListNVMeNamespace(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	ctx := context.Background()
+	id := &pc.ObjectKey{Value: "subsystem-test"}
+
+	// a subsystem without namespaces is no error
+	response, err := c.nvme.ListNVMeNamespace(ctx, &pb.ListNVMeNamespaceRequest{SubsystemId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Namespaces) != 0 {
+		t.Errorf("unexpected namespaces %v", response.Namespaces)
+	}
+
+	createTestNamespace(t, c)
+	// added behind the bridge's back
+	params := NvmfSubsystemAddNsParams{Nqn: testNqn}
+	params.Namespace.BdevName = "Malloc0"
+	if err := call(ctx, "nvmf_subsystem_add_ns", &params, nil); err != nil {
+		t.Fatal(err)
+	}
+	response, err = c.nvme.ListNVMeNamespace(ctx, &pb.ListNVMeNamespaceRequest{SubsystemId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Namespaces) != 2 {
+		t.Fatalf("unexpected namespaces %v", response.Namespaces)
+	}
+	spdk.mu.Lock()
+	malloc0, malloc1 := *spdk.bdevs["Malloc0"], *spdk.bdevs["Malloc1"]
+	spdk.mu.Unlock()
+	want := testNamespace().Spec
+	want.BlockSize = malloc1.blockSize
+	want.BlocksCount = malloc1.numBlocks
+	want.Uuid = &pc.Uuid{Value: malloc1.uuid}
+	want.Nguid = strings.ToUpper(strings.ReplaceAll(malloc1.uuid, "-", ""))
+	if !proto.Equal(response.Namespaces[0].Spec, want) {
+		t.Errorf("expected %v, got %v", want, response.Namespaces[0].Spec)
+	}
+	unmanaged := response.Namespaces[1].Spec
+	if unmanaged.Id.Value != testNqn+"/2" || unmanaged.SubsystemId.Value != "subsystem-test" ||
+		unmanaged.VolumeId.Value != "Malloc0" || unmanaged.Uuid.GetValue() != malloc0.uuid {
+		t.Errorf("unexpected namespace %v", unmanaged)
+	}
+	if status := response.Namespaces[1].Status; status.PciOperState != pb.NVMeNamespacePciOperState_NVME_NAMESPACE_PCI_OPER_STATE_ONLINE {
+		t.Errorf("unexpected status %v", status)
+	}
+
+	// and by its generated ID
+	get, err := c.nvme.GetNVMeNamespace(ctx, &pb.GetNVMeNamespaceRequest{NamespaceId: unmanaged.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(get.Spec, unmanaged) {
+		t.Errorf("expected %v, got %v", unmanaged, get.Spec)
+	}
+
+	all, err := c.nvme.ListNVMeNamespace(ctx, &pb.ListNVMeNamespaceRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all.Namespaces) != 2 {
+		t.Errorf("unexpected namespaces %v", all.Namespaces)
+	}
+	_, err = c.nvme.ListNVMeNamespace(ctx, &pb.ListNVMeNamespaceRequest{SubsystemId: &pc.ObjectKey{Value: "unknown"}})
+	if err == nil {
+		t.Error("expected error for unknown subsystem")
+	}
+}
+
+func TestFrontEnd_GetNVMeNamespace(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestNamespace(t, c)
+	response, err := c.nvme.GetNVMeNamespace(context.Background(), &pb.GetNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Spec.Id.Value != "namespace-test" || response.Spec.HostNsid != 1 ||
+		response.Spec.SubsystemId.Value != "subsystem-test" || response.Spec.VolumeId.Value != "Malloc1" || response.Spec.BlockSize != 512 {
+		t.Errorf("unexpected response %v", response)
+	}
+
+	for _, id := range []string{"unknown", testNqn + "/2", "nqn.2022-09.io.spdk:unknown/1"} {
+		_, err = c.nvme.GetNVMeNamespace(context.Background(), &pb.GetNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: id}})
+		if status.Code(err) != codes.NotFound {
+			t.Errorf("expected NotFound for %s, got %v", id, err)
+		}
+	}
+	spdk.mu.Lock()
+	spdk.subsystems[testNqn].namespaces = nil
+	spdk.mu.Unlock()
+	_, err = c.nvme.GetNVMeNamespace(context.Background(), &pb.GetNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestFrontEnd_NVMeNamespaceStats(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestNamespace(t, c)
+	ctx := context.Background()
+	spdk.mu.Lock()
+	b := spdk.bdevs["Malloc1"]
+	b.bytesRead, b.readOps, b.readLatencyTicks = 8192, 2, 5000
+	b.bytesWritten, b.writeOps, b.writeLatencyTicks = 4096, 1, 3000
+	spdk.mu.Unlock()
+
+	response, err := c.nvme.NVMeNamespaceStats(ctx, &pb.NVMeNamespaceStatsRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the rates are over the second since the fake SPDK started, and its
+	// clock ticks 2000 times a microsecond
+	want := &bridge.VolumeStats{
+		VolumeId: &pc.ObjectKey{Value: "Malloc1"},
+		Read:     &bridge.IoStats{Bytes: 8192, Operations: 2, LatencyUs: 2.5, Iops: 2, Bandwidth: 8192, BytesDelta: 8192, OperationsDelta: 2},
+		Write:    &bridge.IoStats{Bytes: 4096, Operations: 1, LatencyUs: 1.5, Iops: 1, Bandwidth: 4096, BytesDelta: 4096, OperationsDelta: 1},
+		Unmap:    &bridge.IoStats{},
+		Interval: durationpb.New(time.Second),
+		Ticks:    2000000000,
+	}
+	checkVolumeStats(t, response.Stats, want)
+
+	// and so are those of the next one
+	spdk.mu.Lock()
+	b.bytesRead, b.readOps = 24576, 6
+	spdk.mu.Unlock()
+	response, err = c.nvme.NVMeNamespaceStats(ctx, &pb.NVMeNamespaceStatsRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Read = &bridge.IoStats{Bytes: 24576, Operations: 6, LatencyUs: 2.5, Iops: 3, Bandwidth: 12288, BytesDelta: 24576, OperationsDelta: 6}
+	want.Write = &bridge.IoStats{Bytes: 4096, Operations: 1, LatencyUs: 1.5, Iops: 0.5, Bandwidth: 2048, BytesDelta: 4096, OperationsDelta: 1}
+	want.Interval = durationpb.New(2 * time.Second)
+	want.Ticks = 4000000000
+	checkVolumeStats(t, response.Stats, want)
+
+	_, err = c.nvme.NVMeNamespaceStats(ctx, &pb.NVMeNamespaceStatsRequest{NamespaceId: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+// checkVolumeStats compares the stats string of a stats RPC to want
+func checkVolumeStats(t *testing.T, encoded string, want *bridge.VolumeStats) {
+	t.Helper()
+	stats := &bridge.VolumeStats{}
+	if err := protojson.Unmarshal([]byte(encoded), stats); err != nil {
+		t.Fatalf("undecodable stats %q: %v", encoded, err)
+	}
+	if !proto.Equal(stats, want) {
+		t.Errorf("expected %v, got %v", want, stats)
+	}
+}
+
+func TestFrontEnd_VirtioBlk(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	blk := &pb.VirtioBlk{Id: &pc.ObjectKey{Value: "virtio-blk-42"}, VolumeId: &pc.ObjectKey{Value: "Malloc0"}}
+
+	if _, err := c.virtioBlk.CreateVirtioBlk(ctx, &pb.CreateVirtioBlkRequest{Controller: blk}); err != nil {
+		t.Fatal(err)
+	}
+	// a retried create is answered from the registry
+	if _, err := c.virtioBlk.CreateVirtioBlk(ctx, &pb.CreateVirtioBlkRequest{Controller: blk}); err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+	if spdk.called("vhost_create_blk_controller") != 1 {
+		t.Errorf("expected one vhost_create_blk_controller, got %d", spdk.called("vhost_create_blk_controller"))
+	}
+	other := &pb.VirtioBlk{Id: blk.Id, VolumeId: &pc.ObjectKey{Value: "Malloc1"}}
+	_, err := c.virtioBlk.CreateVirtioBlk(ctx, &pb.CreateVirtioBlkRequest{Controller: other})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	list, err := c.virtioBlk.ListVirtioBlk(ctx, &pb.ListVirtioBlkRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Controllers) != 1 || list.Controllers[0].Id.Value != "virtio-blk-42" {
+		t.Errorf("unexpected controllers %v", list.Controllers)
+	}
+	get, err := c.virtioBlk.GetVirtioBlk(ctx, &pb.GetVirtioBlkRequest{ControllerId: &pc.ObjectKey{Value: "virtio-blk-42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Id.Value != "virtio-blk-42" {
+		t.Errorf("unexpected controller %v", get)
+	}
+	if _, err := c.virtioBlk.UpdateVirtioBlk(ctx, &pb.UpdateVirtioBlkRequest{Controller: blk}); err != nil {
+		t.Error(err)
+	}
+	stats, err := c.virtioBlk.VirtioBlkStats(ctx, &pb.VirtioBlkStatsRequest{ControllerId: &pc.ObjectKey{Value: "virtio-blk-42"}})
+	if err != nil {
+		t.Error(err)
+	} else if decoded := (&bridge.VolumeStats{}); protojson.Unmarshal([]byte(stats.Stats), decoded) != nil || decoded.VolumeId.GetValue() != "Malloc0" {
+		t.Errorf("expected the stats of Malloc0, got %v", stats)
+	}
+	if _, err := c.virtioBlk.DeleteVirtioBlk(ctx, &pb.DeleteVirtioBlkRequest{ControllerId: &pc.ObjectKey{Value: "virtio-blk-42"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.virtioBlk.GetVirtioBlk(ctx, &pb.GetVirtioBlkRequest{ControllerId: &pc.ObjectKey{Value: "virtio-blk-42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	spdk.setError("vhost_create_blk_controller", fakeENODEV, "No such device")
+	_, err = c.virtioBlk.CreateVirtioBlk(ctx, &pb.CreateVirtioBlkRequest{Controller: blk})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestFrontEnd_VirtioScsiController(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	controller := &pb.VirtioScsiController{Id: &pc.ObjectKey{Value: "virtio-scsi-42"}}
+
+	if _, err := c.virtioScsi.CreateVirtioScsiController(ctx, &pb.CreateVirtioScsiControllerRequest{Controller: controller}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := c.virtioScsi.ListVirtioScsiController(ctx, &pb.ListVirtioScsiControllerRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Controllers) != 1 || list.Controllers[0].Id.Value != "virtio-scsi-42" {
+		t.Errorf("unexpected controllers %v", list.Controllers)
+	}
+	get, err := c.virtioScsi.GetVirtioScsiController(ctx, &pb.GetVirtioScsiControllerRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Id.Value != "virtio-scsi-42" {
+		t.Errorf("unexpected controller %v", get)
+	}
+	if _, err := c.virtioScsi.UpdateVirtioScsiController(ctx, &pb.UpdateVirtioScsiControllerRequest{Controller: controller}); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.virtioScsi.VirtioScsiControllerStats(ctx, &pb.VirtioScsiControllerStatsRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}}); err != nil {
+		t.Error(err)
+	}
+
+	lun := &pb.VirtioScsiLun{TargetId: &pc.ObjectKey{Value: "virtio-scsi-42"}, VolumeId: &pc.ObjectKey{Value: "Malloc1"}}
+	if _, err := c.virtioScsi.CreateVirtioScsiLun(ctx, &pb.CreateVirtioScsiLunRequest{Lun: lun}); err != nil {
+		t.Fatal(err)
+	}
+	luns, err := c.virtioScsi.ListVirtioScsiLun(ctx, &pb.ListVirtioScsiLunRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(luns.Luns) != 1 {
+		t.Errorf("unexpected luns %v", luns.Luns)
+	}
+	if _, err := c.virtioScsi.GetVirtioScsiLun(ctx, &pb.GetVirtioScsiLunRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}}); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.virtioScsi.UpdateVirtioScsiLun(ctx, &pb.UpdateVirtioScsiLunRequest{Lun: lun}); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.virtioScsi.VirtioScsiLunStats(ctx, &pb.VirtioScsiLunStatsRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}}); err != nil {
+		t.Error(err)
+	}
+	if _, err := c.virtioScsi.DeleteVirtioScsiLun(ctx, &pb.DeleteVirtioScsiLunRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.virtioScsi.DeleteVirtioScsiLun(ctx, &pb.DeleteVirtioScsiLunRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+
+	if _, err := c.virtioScsi.DeleteVirtioScsiController(ctx, &pb.DeleteVirtioScsiControllerRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.virtioScsi.GetVirtioScsiController(ctx, &pb.GetVirtioScsiControllerRequest{ControllerId: &pc.ObjectKey{Value: "virtio-scsi-42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
 
+	spdk.setError("vhost_create_scsi_controller", fakeEEXIST, "File exists")
+	_, err = c.virtioScsi.CreateVirtioScsiController(ctx, &pb.CreateVirtioScsiControllerRequest{Controller: controller})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
 }
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"log"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+func (s *server) CreateNVMfHost(ctx context.Context, in *bridge.CreateNVMfHostRequest) (*bridge.NVMfHost, error) {
+	log.Printf("CreateNVMfHost: Received from client: %v", in)
+	subsys, ok := s.registry.subsystem(in.Host.Spec.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.Host.Spec.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	if in.Host.Spec.HostNqn == "" {
+		err := status.Error(codes.InvalidArgument, "missing host NQN")
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	params := NvmfSubsystemAddHostParams{
+		Nqn:  subsys.Spec.Nqn,
+		Host: in.Host.Spec.HostNqn,
+	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
+	var result NvmfSubsystemAddHostResult
+	existing, err := s.registry.hosts.create(ctx, in.Host.Spec.Id.Value, in.Host, recreating(ctx), func() error {
+		return tx.call("nvmf_subsystem_add_host", &params, &result,
+			undoCall("nvmf_subsystem_remove_host", &NvmfSubsystemRemoveHostParams{Nqn: params.Nqn, Host: params.Host}))
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("CreateNVMfHost: %s already exists", in.Host.Spec.Id.Value)
+		return existing.(*bridge.NVMfHost), nil
+	}
+	log.Printf("Received from SPDK: %v", result)
+	return in.Host, nil
+}
+
+func (s *server) DeleteNVMfHost(ctx context.Context, in *bridge.DeleteNVMfHostRequest) (*emptypb.Empty, error) {
+	log.Printf("DeleteNVMfHost: Received from client: %v", in)
+	host, ok := s.registry.host(in.HostId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find host %s", in.HostId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	subsys, ok := s.registry.subsystem(host.Spec.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", host.Spec.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	params := NvmfSubsystemRemoveHostParams{
+		Nqn:  subsys.Spec.Nqn,
+		Host: host.Spec.HostNqn,
+	}
+	var result NvmfSubsystemRemoveHostResult
+	err := s.registry.hosts.remove(ctx, in.HostId.Value, func() error {
+		return call(ctx, "nvmf_subsystem_remove_host", &params, &result)
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	return &emptypb.Empty{}, nil
+}
+
+// ListNVMfHost returns the hosts SPDK allows on the subsystem, including
+// those added behind the bridge's back, which have no ID
+func (s *server) ListNVMfHost(ctx context.Context, in *bridge.ListNVMfHostRequest) (*bridge.ListNVMfHostResponse, error) {
+	log.Printf("ListNVMfHost: Received from client: %v", in)
+	subsys, ok := s.registry.subsystem(in.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	result, err := getSubsystem(ctx, subsys.Spec.Nqn)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	ids := map[string]*pc.ObjectKey{}
+	for _, m := range s.registry.hosts.values() {
+		host := m.(*bridge.NVMfHost)
+		if host.Spec.SubsystemId.Value == in.SubsystemId.Value {
+			ids[host.Spec.HostNqn] = host.Spec.Id
+		}
+	}
+	Blobarray := make([]*bridge.NVMfHost, len(result.Hosts))
+	for i := range result.Hosts {
+		nqn := result.Hosts[i].Nqn
+		Blobarray[i] = &bridge.NVMfHost{Spec: &bridge.NVMfHostSpec{
+			Id:          ids[nqn],
+			SubsystemId: &pc.ObjectKey{Value: in.SubsystemId.Value},
+			HostNqn:     nqn,
+		}}
+	}
+	return &bridge.ListNVMfHostResponse{Hosts: Blobarray}, nil
+}
+
+// UpdateNVMfSubsystemAccess lets any host connect to the subsystem or only
+// the allowed ones. The setting is kept for when the subsystem is created
+// again.
+func (s *server) UpdateNVMfSubsystemAccess(ctx context.Context, in *bridge.UpdateNVMfSubsystemAccessRequest) (*bridge.NVMfSubsystemAccess, error) {
+	log.Printf("UpdateNVMfSubsystemAccess: Received from client: %v", in)
+	subsys, ok := s.registry.subsystem(in.Access.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.Access.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	access := &bridge.NVMfSubsystemAccess{
+		SubsystemId:  in.Access.SubsystemId,
+		AllowAnyHost: in.Access.AllowAnyHost,
+	}
+	params := NvmfSubsystemAllowAnyHostParams{
+		Nqn:          subsys.Spec.Nqn,
+		AllowAnyHost: in.Access.AllowAnyHost,
+	}
+	var result NvmfSubsystemAllowAnyHostResult
+	err := s.registry.accesses.put(ctx, in.Access.SubsystemId.Value, access, func() error {
+		return call(ctx, "nvmf_subsystem_allow_any_host", &params, &result)
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	return s.subsystemAccess(ctx, subsys)
+}
+
+func (s *server) GetNVMfSubsystemAccess(ctx context.Context, in *bridge.GetNVMfSubsystemAccessRequest) (*bridge.NVMfSubsystemAccess, error) {
+	log.Printf("GetNVMfSubsystemAccess: Received from client: %v", in)
+	subsys, ok := s.registry.subsystem(in.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return s.subsystemAccess(ctx, subsys)
+}
+
+// subsystemAccess returns the access control SPDK has for subsys
+func (s *server) subsystemAccess(ctx context.Context, subsys *pb.NVMeSubsystem) (*bridge.NVMfSubsystemAccess, error) {
+	result, err := getSubsystem(ctx, subsys.Spec.Nqn)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	return accessOf(subsys, result), nil
+}
+
+// accessOf returns the access control of subsys in result
+func accessOf(subsys *pb.NVMeSubsystem, result *NvmfGetSubsystemsResult) *bridge.NVMfSubsystemAccess {
+	access := &bridge.NVMfSubsystemAccess{
+		SubsystemId:  subsys.Spec.Id,
+		AllowAnyHost: result.AllowAnyHost,
+		HostNqns:     make([]string, len(result.Hosts)),
+	}
+	for i := range result.Hosts {
+		access.HostNqns[i] = result.Hosts[i].Nqn
+	}
+	return access
+}
+
+// getSubsystem returns what SPDK has for the subsystem nqn
+func getSubsystem(ctx context.Context, nqn string) (*NvmfGetSubsystemsResult, error) {
+	var result []NvmfGetSubsystemsResult
+	if err := call(ctx, "nvmf_get_subsystems", nil, &result); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if result[i].Nqn == nqn {
+			return &result[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "Could not find NQN: %s", nqn)
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+const testHostNqn = "nqn.2014-08.org.nvmexpress:uuid:feb98abe-d51f-40c8-b348-2753f3571d3c"
+
+func testHost() *bridge.NVMfHost {
+	return &bridge.NVMfHost{Spec: &bridge.NVMfHostSpec{
+		Id:          &pc.ObjectKey{Value: "host-test"},
+		SubsystemId: &pc.ObjectKey{Value: "subsystem-test"},
+		HostNqn:     testHostNqn,
+	}}
+}
+
+func createTestHost(t *testing.T, c *bridgeClients) {
+	_, err := c.host.CreateNVMfHost(context.Background(), &bridge.CreateNVMfHostRequest{Host: testHost()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func restrictTestSubsystem(t *testing.T, c *bridgeClients) {
+	access := &bridge.NVMfSubsystemAccess{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}}
+	_, err := c.host.UpdateNVMfSubsystemAccess(context.Background(), &bridge.UpdateNVMfSubsystemAccessRequest{Access: access})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHost_Create(t *testing.T) {
+	tests := map[string]struct {
+		change  func(h *bridge.NVMfHost)
+		spdkErr int
+		code    codes.Code
+	}{
+		"valid request":      {func(h *bridge.NVMfHost) {}, 0, codes.OK},
+		"unknown subsystem":  {func(h *bridge.NVMfHost) { h.Spec.SubsystemId.Value = "unknown" }, 0, codes.NotFound},
+		"missing host NQN":   {func(h *bridge.NVMfHost) { h.Spec.HostNqn = "" }, 0, codes.InvalidArgument},
+		"invalid host NQN":   {func(h *bridge.NVMfHost) { h.Spec.HostNqn = "host" }, 0, codes.InvalidArgument},
+		"invalid parameters": {func(h *bridge.NVMfHost) {}, fakeInvalidParams, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			createTestSubsystem(t, c)
+			if tt.spdkErr != 0 {
+				spdk.setError("nvmf_subsystem_add_host", tt.spdkErr, "failed")
+			}
+			host := testHost()
+			tt.change(host)
+			response, err := c.host.CreateNVMfHost(context.Background(), &bridge.CreateNVMfHostRequest{Host: host})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if err == nil && !proto.Equal(response, host) {
+				t.Errorf("expected %v, got %v", host, response)
+			}
+			if _, ok := c.server.registry.host("host-test"); ok != (err == nil) {
+				t.Errorf("host recorded %v on error %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestHost_List(t *testing.T) {
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestHost(t, c)
+	ctx := context.Background()
+	// added behind the bridge's back
+	params := NvmfSubsystemAddHostParams{Nqn: testNqn, Host: "nqn.2022-11.io.opiproject:host"}
+	if err := call(ctx, "nvmf_subsystem_add_host", &params, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := c.host.ListNVMfHost(ctx, &bridge.ListNVMfHostRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Hosts) != 2 {
+		t.Fatalf("unexpected hosts %v", response.Hosts)
+	}
+	if !proto.Equal(response.Hosts[0], testHost()) {
+		t.Errorf("expected %v, got %v", testHost(), response.Hosts[0])
+	}
+	if unmanaged := response.Hosts[1].Spec; unmanaged.Id != nil || unmanaged.HostNqn != params.Host {
+		t.Errorf("unexpected host %v", unmanaged)
+	}
+
+	_, err = c.host.ListNVMfHost(ctx, &bridge.ListNVMfHostRequest{SubsystemId: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestHost_Delete(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestHost(t, c)
+	ctx := context.Background()
+
+	spdk.failNext("nvmf_subsystem_remove_host", fakeInvalidParams, "failed")
+	_, err := c.host.DeleteNVMfHost(ctx, &bridge.DeleteNVMfHostRequest{HostId: &pc.ObjectKey{Value: "host-test"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if _, ok := c.server.registry.host("host-test"); !ok {
+		t.Error("host forgotten although SPDK still allows it")
+	}
+
+	if _, err := c.host.DeleteNVMfHost(ctx, &bridge.DeleteNVMfHostRequest{HostId: &pc.ObjectKey{Value: "host-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	spdk.mu.Lock()
+	left := len(spdk.subsystems[testNqn].hosts)
+	spdk.mu.Unlock()
+	if left != 0 {
+		t.Errorf("host left in SPDK")
+	}
+	_, err = c.host.DeleteNVMfHost(ctx, &bridge.DeleteNVMfHostRequest{HostId: &pc.ObjectKey{Value: "host-test"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for deleted host, got %v", err)
+	}
+}
+
+func TestHost_Access(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestHost(t, c)
+	ctx := context.Background()
+	id := &pc.ObjectKey{Value: "subsystem-test"}
+
+	response, err := c.host.GetNVMfSubsystemAccess(ctx, &bridge.GetNVMfSubsystemAccessRequest{SubsystemId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.AllowAnyHost || !reflect.DeepEqual(response.HostNqns, []string{testHostNqn}) {
+		t.Errorf("unexpected access %v", response)
+	}
+
+	restrict := &bridge.UpdateNVMfSubsystemAccessRequest{Access: &bridge.NVMfSubsystemAccess{SubsystemId: id}}
+	spdk.failNext("nvmf_subsystem_allow_any_host", fakeInvalidParams, "failed")
+	if _, err := c.host.UpdateNVMfSubsystemAccess(ctx, restrict); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if !c.server.registry.allowAnyHost("subsystem-test") {
+		t.Error("access recorded although SPDK refused it")
+	}
+	response, err = c.host.UpdateNVMfSubsystemAccess(ctx, restrict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.AllowAnyHost || !reflect.DeepEqual(response.HostNqns, []string{testHostNqn}) {
+		t.Errorf("unexpected access %v", response)
+	}
+
+	// the subsystem comes back restricted
+	if _, err := c.host.DeleteNVMfHost(ctx, &bridge.DeleteNVMfHostRequest{HostId: &pc.ObjectKey{Value: "host-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: id}); err != nil {
+		t.Fatal(err)
+	}
+	createTestSubsystem(t, c)
+	spdk.mu.Lock()
+	allowAnyHost := spdk.subsystems[testNqn].allowAnyHost
+	spdk.mu.Unlock()
+	if allowAnyHost {
+		t.Error("expected the subsystem created without allow-any-host")
+	}
+
+	_, err = c.host.GetNVMfSubsystemAccess(ctx, &bridge.GetNVMfSubsystemAccessRequest{SubsystemId: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
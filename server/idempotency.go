@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var requestIDWindow = flag.Duration("request_id_window", 10*time.Minute, "How long the response to a request carrying an x-request-id header is replayed to retries")
+
+// requestIDHeader is the gRPC metadata key clients put a unique ID of
+// every mutating request in, so that the request can be retried safely
+const requestIDHeader = "x-request-id"
+
+// requestCache remembers the successful responses of mutating requests by
+// their request ID. A retry within the window gets the first response
+// back without SPDK being called again.
+type requestCache struct {
+	window time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	entries map[requestKey]*requestEntry
+}
+
+type requestKey struct {
+	method string
+	id     string
+}
+
+type requestEntry struct {
+	req  proto.Message
+	done chan struct{}
+	// set once done is closed
+	resp    interface{}
+	expires time.Time
+}
+
+func newRequestCache(window time.Duration) *requestCache {
+	return &requestCache{window: window, now: time.Now, entries: map[requestKey]*requestEntry{}}
+}
+
+// mutating tells the methods changing SPDK from the ones only reading it
+func mutating(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, verb := range []string{"Create", "Delete", "Update", "Connect", "Disconnect"} {
+		if strings.Contains(name, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+func requestID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	ids := md.Get(requestIDHeader)
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
+
+// unaryInterceptor replays responses to requests whose ID was seen before.
+// A retry arriving while the first request is still running waits for it.
+// Only successes are remembered, so a failed request can be retried.
+func (c *requestCache) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	id := requestID(ctx)
+	msg, ok := req.(proto.Message)
+	if id == "" || !ok || !mutating(info.FullMethod) {
+		return handler(ctx, req)
+	}
+	key := requestKey{method: info.FullMethod, id: id}
+
+	for {
+		c.mu.Lock()
+		e, ok := c.entries[key]
+		if ok && e.expires.IsZero() {
+			c.mu.Unlock()
+			select {
+			case <-e.done:
+				// the first request may have failed and been forgotten
+				continue
+			case <-ctx.Done():
+				return nil, status.FromContextError(ctx.Err()).Err()
+			}
+		}
+		if ok && c.now().Before(e.expires) {
+			c.mu.Unlock()
+			if !proto.Equal(e.req, msg) {
+				return nil, status.Errorf(codes.InvalidArgument, "request ID %s was used for a different request", id)
+			}
+			log.Printf("%s: replaying response to request %s", info.FullMethod, id)
+			return e.resp, nil
+		}
+		c.sweep()
+		e = &requestEntry{req: proto.Clone(msg), done: make(chan struct{})}
+		c.entries[key] = e
+		c.mu.Unlock()
+		return c.run(ctx, key, e, req, handler)
+	}
+}
+
+// run calls handler for the entry e just added under key, and completes
+// e when it returns. A handler that panics leaves nothing behind, like a
+// failed one.
+func (c *requestCache) run(ctx context.Context, key requestKey, e *requestEntry, req interface{}, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	returned := false
+	defer func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if returned && err == nil {
+			e.resp, e.expires = resp, c.now().Add(c.window)
+		} else {
+			delete(c.entries, key)
+		}
+		close(e.done)
+	}()
+	resp, err = handler(ctx, req)
+	returned = true
+	return resp, err
+}
+
+// sweep forgets the expired entries, c.mu held
+func (c *requestCache) sweep() {
+	now := c.now()
+	for key, e := range c.entries {
+		if !e.expires.IsZero() && !now.Before(e.expires) {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func withRequestID(id string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), requestIDHeader, id)
+}
+
+func TestIdempotency_CreateRetried(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		subsystem, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: testSubsystem()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if subsystem.Spec.Nqn != testNqn {
+			t.Errorf("unexpected subsystem %v", subsystem)
+		}
+	}
+	if spdk.called("nvmf_create_subsystem") != 1 {
+		t.Errorf("expected one nvmf_create_subsystem, got %d", spdk.called("nvmf_create_subsystem"))
+	}
+
+	other := testSubsystem()
+	other.Spec.Nqn = "nqn.2022-09.io.spdk:opi2"
+	_, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: other})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+}
+
+func TestIdempotency_RequestID(t *testing.T) {
+	spdk, c := startBridge(t)
+	device := &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}
+	handle := &pc.ObjectKey{Value: "Null42"}
+
+	if _, err := c.null.NullDebugCreate(withRequestID("create"), &pb.NullDebugCreateRequest{Device: device}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.null.NullDebugDelete(withRequestID("delete"), &pb.NullDebugDeleteRequest{Handle: handle}); err != nil {
+		t.Fatal(err)
+	}
+	// the retried delete is answered without SPDK, which no longer knows
+	// the bdev
+	if _, err := c.null.NullDebugDelete(withRequestID("delete"), &pb.NullDebugDeleteRequest{Handle: handle}); err != nil {
+		t.Errorf("expected the retry to be replayed, got %v", err)
+	}
+	if spdk.called("bdev_null_delete") != 1 {
+		t.Errorf("expected one bdev_null_delete, got %d", spdk.called("bdev_null_delete"))
+	}
+
+	_, err := c.null.NullDebugDelete(withRequestID("create"), &pb.NullDebugDeleteRequest{Handle: handle})
+	if err == nil {
+		t.Error("expected the request ID of a different method not to be replayed")
+	}
+	other := &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null43"}}
+	_, err = c.null.NullDebugCreate(withRequestID("create"), &pb.NullDebugCreateRequest{Device: other})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a reused request ID, got %v", err)
+	}
+}
+
+func TestIdempotency_Window(t *testing.T) {
+	cache := newRequestCache(time.Minute)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	info := &grpc.UnaryServerInfo{FullMethod: "/opi_api.storage.v1.NullDebugService/NullDebugCreate"}
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			return nil, errors.New("failed")
+		}
+		return &pb.NullDebug{}, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "42"))
+	req := &pb.NullDebugCreateRequest{}
+
+	tests := []struct {
+		advance time.Duration
+		fails   bool
+		calls   int
+	}{
+		// failures are not remembered
+		{0, true, 1},
+		{0, false, 2},
+		{59 * time.Second, false, 2},
+		{time.Second, false, 3},
+	}
+	for i, tt := range tests {
+		now = now.Add(tt.advance)
+		_, err := cache.unaryInterceptor(ctx, req, info, handler)
+		if (err != nil) != tt.fails || calls != tt.calls {
+			t.Errorf("%d: unexpected error %v after %d calls", i, err, calls)
+		}
+	}
+
+	// reads are never cached
+	info.FullMethod = "/opi_api.storage.v1.NullDebugService/NullDebugGet"
+	if _, err := cache.unaryInterceptor(ctx, req, info, handler); err != nil || calls != 4 {
+		t.Errorf("unexpected error %v after %d calls", err, calls)
+	}
+	if _, err := cache.unaryInterceptor(ctx, req, info, handler); err != nil || calls != 5 {
+		t.Errorf("unexpected error %v after %d calls", err, calls)
+	}
+}
+
+func TestIdempotency_InFlight(t *testing.T) {
+	cache := newRequestCache(time.Minute)
+	info := &grpc.UnaryServerInfo{FullMethod: "/opi_api.storage.v1.NullDebugService/NullDebugCreate"}
+	release := make(chan struct{})
+	var mu sync.Mutex
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		<-release
+		return &pb.NullDebug{}, nil
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "42"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := cache.unaryInterceptor(ctx, &pb.NullDebugCreateRequest{}, info, handler); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls != 1 {
+		t.Errorf("expected one call for concurrent retries, got %d", calls)
+	}
+}
+
+// a retry of a request whose handler panicked runs it again instead of
+// waiting for it forever
+func TestIdempotency_Panic(t *testing.T) {
+	cache := newRequestCache(time.Minute)
+	info := &grpc.UnaryServerInfo{FullMethod: "/opi_api.storage.v1.NullDebugService/NullDebugCreate"}
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		if calls == 1 {
+			panic("failed")
+		}
+		return &pb.NullDebug{}, nil
+	}
+	ctx, cancel := context.WithTimeout(metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDHeader, "42")), 5*time.Second)
+	defer cancel()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to reach the recovery interceptor")
+			}
+		}()
+		_, _ = cache.unaryInterceptor(ctx, &pb.NullDebugCreateRequest{}, info, handler)
+	}()
+	if _, err := cache.unaryInterceptor(ctx, &pb.NullDebugCreateRequest{}, info, handler); err != nil || calls != 2 {
+		t.Errorf("unexpected error %v after %d calls", err, calls)
+	}
+}
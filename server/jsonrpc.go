@@ -5,29 +5,363 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
-	rpcID   int32 // json request message ID, auto incremented
-	rpcSock = flag.String("rpc_sock", "/var/tmp/spdk.sock", "Path to SPDK JSON RPC socket")
+	rpcID             int32 // json request message ID, auto incremented
+	rpcSock           = flag.String("rpc_sock", "/var/tmp/spdk.sock", "Path or URL of SPDK JSON RPC server, e.g. unix:///var/tmp/spdk.sock or https://user@host:9009")
+	rpcCA             = flag.String("rpc_ca", "", "PEM file with the CA certificates trusted for https SPDK JSON RPC servers")
+	rpcPasswordFile   = flag.String("rpc_password_file", "", "File holding the password of the -rpc_sock user, $SPDK_RPC_PASSWORD is used without it")
+	rpcTimeout        = flag.Duration("rpc_timeout", 30*time.Second, "Default timeout of a SPDK JSON RPC call when the client sets no deadline")
+	rpcMethodTimeouts = methodTimeoutsFlag("rpc_method_timeout", "Override of -rpc_timeout for one SPDK method as method=duration, may be repeated")
+	rpc               rpcTransport
+
+	// calls that failed to reach SPDK since the last one that did, updated
+	// atomically. Failures since startup are counted by spdkCallErrors.
+	rpcConsecutiveFailures uint64
 )
 
+// methodTimeouts is a flag.Value collecting per SPDK method timeouts
+type methodTimeouts map[string]time.Duration
+
+func methodTimeoutsFlag(name, usage string) methodTimeouts {
+	m := methodTimeouts{}
+	flag.Var(m, name, usage)
+	return m
+}
+
+func (m methodTimeouts) String() string {
+	pairs := make([]string, 0, len(m))
+	for method, timeout := range m {
+		pairs = append(pairs, method+"="+timeout.String())
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (m methodTimeouts) Set(value string) error {
+	pair := strings.SplitN(value, "=", 2)
+	if len(pair) != 2 {
+		return fmt.Errorf("expected method=duration, got %q", value)
+	}
+	d, err := time.ParseDuration(pair[1])
+	if err != nil {
+		return err
+	}
+	m[pair[0]] = d
+	return nil
+}
+
+// callTimeout returns the timeout applied to a method call without deadline
+func callTimeout(method string) time.Duration {
+	if timeout, ok := rpcMethodTimeouts[method]; ok {
+		return timeout
+	}
+	return *rpcTimeout
+}
+
+// rpcTransport delivers an encoded JSON RPC request to SPDK and returns
+// the response carrying the same ID
+type rpcTransport interface {
+	exchange(ctx context.Context, id int32, data []byte) (*rpcResponse, error)
+}
+
+// rpcPassword returns the password of the user in -rpc_sock, read from
+// -rpc_password_file or the environment so it stays out of process listings
+func rpcPassword() (string, error) {
+	if *rpcPasswordFile == "" {
+		return os.Getenv("SPDK_RPC_PASSWORD"), nil
+	}
+	data, err := os.ReadFile(*rpcPasswordFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// newTransport selects the transport from the scheme of addr. A plain
+// path is treated as a unix socket for backwards compatibility.
+func newTransport(addr, caFile, password string) (rpcTransport, error) {
+	if !strings.Contains(addr, "://") {
+		return newSocketTransport("unix", addr), nil
+	}
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "unix":
+		return newSocketTransport("unix", u.Path), nil
+	case "http", "https":
+		return newHTTPTransport(u, caFile, password)
+	default:
+		return nil, fmt.Errorf("unsupported SPDK JSON RPC scheme %q", u.Scheme)
+	}
+}
+
+// rpcResponse is a single JSON RPC response as received from SPDK
+type rpcResponse struct {
+	ID     int32           `json:"id"`
+	Error  *rpcError       `json:"error,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// rpcError is the error object of a failed JSON RPC request
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// socketTransport is a long-lived JSON RPC client that pipelines concurrent
+// requests over a single connection to SPDK and matches the responses
+// back to their callers by request ID. The connection is re-established
+// on the next request after it is lost, e.g. when SPDK restarts.
+type socketTransport struct {
+	network string
+	address string
+
+	// held while dialing and writing a request, so requests never
+	// interleave, without holding up the delivery of responses
+	writing chan struct{}
+
+	mu      sync.Mutex // guards conn and pending
+	conn    net.Conn
+	pending map[int32]chan<- rpcResult
+}
+
+// rpcResult is delivered to the caller waiting on a request ID
+type rpcResult struct {
+	response rpcResponse
+	err      error
+}
+
+var errConnectionClosed = errors.New("connection to SPDK closed")
+
+func newSocketTransport(network, address string) *socketTransport {
+	return &socketTransport{
+		network: network,
+		address: address,
+		writing: make(chan struct{}, 1),
+		pending: map[int32]chan<- rpcResult{},
+	}
+}
+
+// exchange sends an already encoded request and waits for the response
+// carrying the same ID, or until ctx is done
+func (c *socketTransport) exchange(ctx context.Context, id int32, data []byte) (*rpcResponse, error) {
+	ch := make(chan rpcResult, 1)
+	if err := c.send(ctx, id, data, ch); err != nil {
+		return nil, err
+	}
+	select {
+	case res := <-ch:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return &res.response, nil
+	case <-ctx.Done():
+		// a late response for this ID is dropped by the receiver
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		// unless it arrived meanwhile
+		select {
+		case res := <-ch:
+			if res.err == nil {
+				return &res.response, nil
+			}
+		default:
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func (c *socketTransport) send(ctx context.Context, id int32, data []byte, ch chan<- rpcResult) error {
+	select {
+	case c.writing <- struct{}{}:
+		defer func() { <-c.writing }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	c.mu.Lock()
+	conn := c.conn
+	c.mu.Unlock()
+	if conn == nil {
+		var d net.Dialer
+		var err error
+		conn, err = d.DialContext(ctx, c.network, c.address)
+		if err != nil {
+			return err
+		}
+		c.mu.Lock()
+		c.conn = conn
+		c.mu.Unlock()
+		go c.receive(conn)
+	}
+	c.mu.Lock()
+	c.pending[id] = ch
+	c.mu.Unlock()
+	// a write stuck on a hung SPDK must not outlive the caller
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetWriteDeadline(deadline); err != nil {
+		c.close(conn, err)
+		return err
+	}
+	if _, err := conn.Write(data); err != nil {
+		c.close(conn, err)
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// receive decodes the stream of responses on conn until it fails
+func (c *socketTransport) receive(conn net.Conn) {
+	decoder := json.NewDecoder(bufio.NewReader(conn))
+	for {
+		var response rpcResponse
+		if err := decoder.Decode(&response); err != nil {
+			c.close(conn, err)
+			return
+		}
+		c.mu.Lock()
+		ch, ok := c.pending[response.ID]
+		delete(c.pending, response.ID)
+		c.mu.Unlock()
+		if !ok {
+			log.Printf("Dropping SPDK response with unknown ID %d", response.ID)
+			continue
+		}
+		ch <- rpcResult{response: response}
+	}
+}
+
+// close tears down conn, unless it was replaced already, and fails every
+// request still waiting on it
+func (c *socketTransport) close(conn net.Conn, cause error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != conn {
+		return
+	}
+	if err := c.conn.Close(); err != nil {
+		log.Printf("error closing SPDK connection: %v", err)
+	}
+	c.conn = nil
+	err := fmt.Errorf("%w: %v", errConnectionClosed, cause)
+	for id, ch := range c.pending {
+		ch <- rpcResult{err: err}
+		delete(c.pending, id)
+	}
+}
+
+// httpTransport posts every request to SPDK's rpc_http_proxy.py
+type httpTransport struct {
+	url      string
+	user     string
+	password string
+	client   *http.Client
+}
+
+func newHTTPTransport(u *url.URL, caFile, password string) (*httpTransport, error) {
+	t := &httpTransport{
+		client: &http.Client{},
+	}
+	if u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			return nil, errors.New("SPDK JSON RPC password in URL, pass it with -rpc_password_file or $SPDK_RPC_PASSWORD")
+		}
+		t.user = u.User.Username()
+		t.password = password
+	}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		t.client.Transport = &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+		}
+	}
+	// the user is sent in the basic auth header, not as part of the URL
+	stripped := *u
+	stripped.User = nil
+	t.url = stripped.String()
+	return t, nil
+}
+
+func (t *httpTransport) exchange(ctx context.Context, id int32, data []byte) (*rpcResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if t.user != "" {
+		req.SetBasicAuth(t.user, t.password)
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http status %s", resp.Status)
+	}
+	var response rpcResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if response.ID != id {
+		return nil, fmt.Errorf("json response ID mismatch")
+	}
+	return &response, nil
+}
+
+// call sends a JSON RPC request to SPDK and decodes its result, aborted
+// when ctx is done
+func call(ctx context.Context, method string, args, result interface{}) error {
+	start := time.Now()
+	id := atomic.AddInt32(&rpcID, 1)
+	ctx, span := startCallSpan(ctx, method, id)
+	err := exchangeCall(ctx, id, method, args, result)
+	endCallSpan(span, err)
+	observeSpdkCall(method, start, err)
+	return err
+}
+
 // low level rpc request/response handling
-func call(method string, args, result interface{}) error {
+func exchangeCall(ctx context.Context, id int32, method string, args, result interface{}) error {
 	type rpcRequest struct {
 		Ver    string `json:"jsonrpc"`
 		ID     int32  `json:"id"`
 		Method string `json:"method"`
 	}
 
-	id := atomic.AddInt32(&rpcID, 1)
 	request := rpcRequest{
 		Ver:    "2.0",
 		ID:     id,
@@ -53,53 +387,39 @@ func call(method string, args, result interface{}) error {
 		return fmt.Errorf("%s: %s", method, err)
 	}
 
-	log.Printf("Sending to SPDK: %s", data)
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, callTimeout(method))
+		defer cancel()
+	}
 
-	// TODO: add also web option: resp, _ = webSocketCom(rpcClient, data)
-	resp, _ := unixSocketCom(*rpcSock, data)
+	log.Printf("Sending to SPDK: %s", data)
 
-	response := struct {
-		ID    int32 `json:"id"`
-		Error struct {
-			Code    int    `json:"code"`
-			Message string `json:"message"`
-		} `json:"error"`
-		Result interface{} `json:"result"`
-	}{
-		Result: result,
+	response, err := rpc.exchange(ctx, id, data)
+	if err != nil && ctx.Err() != nil {
+		// report as DeadlineExceeded or Canceled whatever the transport saw
+		return status.Errorf(status.FromContextError(ctx.Err()).Code(), "%s: %s", method, ctx.Err())
 	}
-	err = json.NewDecoder(resp).Decode(&response)
-	jsonresponse, _ := json.Marshal(response)
-	log.Printf("Received from SPDK: %s", jsonresponse)
 	if err != nil {
-		return fmt.Errorf("%s: %s", method, err)
-	}
-	if response.ID != id {
-		return fmt.Errorf("%s: json response ID mismatch", method)
-	}
-	if response.Error.Code != 0 {
-		return fmt.Errorf("%s: json response error: %s", method, response.Error.Message)
+		if atomic.AddUint64(&rpcConsecutiveFailures, 1) == 1 {
+			log.Printf("SPDK unreachable: %v", err)
+		}
+		return status.Errorf(codes.Unavailable, "%s: %s", method, err)
 	}
-
-	return nil
-}
-
-func unixSocketCom(rpcSock string, buf []byte) (io.Reader, error) {
-	conn, err := net.Dial("unix", rpcSock)
-	if err != nil {
-		log.Fatal(err)
+	if n := atomic.SwapUint64(&rpcConsecutiveFailures, 0); n != 0 {
+		log.Printf("SPDK reachable again after %d failed calls", n)
 	}
-	_, err = conn.Write(buf)
-	if err != nil {
-		log.Fatal(err)
-		return nil, err
+	jsonresponse, _ := json.Marshal(response)
+	log.Printf("Received from SPDK: %s", jsonresponse)
+	if response.Error != nil && response.Error.Code != 0 {
+		return &SpdkError{Method: method, Code: response.Error.Code, Message: response.Error.Message}
 	}
-
-	err = conn.(*net.UnixConn).CloseWrite()
-	if err != nil {
-		log.Fatal(err)
-		return nil, err
+	if result != nil && len(response.Result) != 0 {
+		err = json.Unmarshal(response.Result, result)
+		if err != nil {
+			return fmt.Errorf("%s: %s", method, err)
+		}
 	}
 
-	return bufio.NewReader(conn), nil
+	return nil
 }
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// SPDK names of the transport types, address families and ANA states.
+// vfio-user emulates a PCIe controller, so it stands for NVMe/PCIe.
+var (
+	spdkTrtypes = map[pb.NvmeTransportType]string{
+		pb.NvmeTransportType_NVME_TRANSPORT_TCP:  "TCP",
+		pb.NvmeTransportType_NVME_TRANSPORT_RDMA: "RDMA",
+		pb.NvmeTransportType_NVME_TRANSPORT_FC:   "FC",
+		pb.NvmeTransportType_NVME_TRANSPORT_PCIE: "VFIOUSER",
+	}
+	spdkAdrfams = map[pb.NvmeAddressFamily]string{
+		pb.NvmeAddressFamily_NVMF_ADRFAM_IPV4:       "IPv4",
+		pb.NvmeAddressFamily_NVMF_ADRFAM_IPV6:       "IPv6",
+		pb.NvmeAddressFamily_NVMF_ADRFAM_IB:         "IB",
+		pb.NvmeAddressFamily_NVMF_ADRFAM_FC:         "FC",
+		pb.NvmeAddressFamily_NVMF_ADRFAM_INTRA_HOST: "INTRA_HOST",
+	}
+	spdkAnaStates = map[bridge.NVMfAnaState]string{
+		bridge.NVMfAnaState_NVMF_ANA_STATE_OPTIMIZED:     "optimized",
+		bridge.NVMfAnaState_NVMF_ANA_STATE_NON_OPTIMIZED: "non_optimized",
+		bridge.NVMfAnaState_NVMF_ANA_STATE_INACCESSIBLE:  "inaccessible",
+	}
+)
+
+// listenAddress converts the address of spec to SPDK's
+func listenAddress(spec *bridge.NVMfListenerSpec) (NvmfListenAddress, error) {
+	trtype, ok := spdkTrtypes[spec.Trtype]
+	if !ok {
+		return NvmfListenAddress{}, status.Errorf(codes.InvalidArgument, "unsupported transport type %v", spec.Trtype)
+	}
+	addr := NvmfListenAddress{Trtype: trtype, Traddr: spec.Traddr, Trsvcid: spec.Trsvcid}
+	if spec.Adrfam != pb.NvmeAddressFamily_NVME_ADDRESS_FAMILY_UNSPECIFIED {
+		adrfam, ok := spdkAdrfams[spec.Adrfam]
+		if !ok {
+			return NvmfListenAddress{}, status.Errorf(codes.InvalidArgument, "unsupported address family %v", spec.Adrfam)
+		}
+		addr.Adrfam = adrfam
+	}
+	return addr, nil
+}
+
+// sameListenAddress compares addresses the way SPDK does, which reports
+// names in its own case
+func sameListenAddress(a, b NvmfListenAddress) bool {
+	return strings.EqualFold(a.Trtype, b.Trtype) && strings.EqualFold(a.Adrfam, b.Adrfam) &&
+		a.Traddr == b.Traddr && a.Trsvcid == b.Trsvcid
+}
+
+// listenerSpec converts an address SPDK reports back to a spec
+func listenerSpec(addr NvmfListenAddress) *bridge.NVMfListenerSpec {
+	spec := &bridge.NVMfListenerSpec{Traddr: addr.Traddr, Trsvcid: addr.Trsvcid}
+	for trtype, name := range spdkTrtypes {
+		if strings.EqualFold(name, addr.Trtype) {
+			spec.Trtype = trtype
+		}
+	}
+	for adrfam, name := range spdkAdrfams {
+		if strings.EqualFold(name, addr.Adrfam) {
+			spec.Adrfam = adrfam
+		}
+	}
+	return spec
+}
+
+func anaState(r *NvmfSubsystemGetListenersResult) bridge.NVMfAnaState {
+	if len(r.AnaStates) == 0 {
+		return bridge.NVMfAnaState_NVMF_ANA_STATE_UNSPECIFIED
+	}
+	for state, name := range spdkAnaStates {
+		if name == r.AnaStates[0].AnaState {
+			return state
+		}
+	}
+	return bridge.NVMfAnaState_NVMF_ANA_STATE_UNSPECIFIED
+}
+
+func (s *server) CreateNVMfListener(ctx context.Context, in *bridge.CreateNVMfListenerRequest) (*bridge.NVMfListener, error) {
+	log.Printf("CreateNVMfListener: Received from client: %v", in)
+	subsys, ok := s.registry.subsystem(in.Listener.Spec.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.Listener.Spec.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	addr, err := listenAddress(in.Listener.Spec)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	params := NvmfSubsystemAddListenerParams{
+		Nqn:           subsys.Spec.Nqn,
+		ListenAddress: addr,
+	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
+	var result NvmfSubsystemAddListenerResult
+	existing, err := s.registry.listeners.create(ctx, in.Listener.Spec.Id.Value, in.Listener, recreating(ctx), func() error {
+		if err := checkTransport(ctx, addr.Trtype); err != nil {
+			return err
+		}
+		err := tx.call("nvmf_subsystem_add_listener", &params, &result,
+			undoCall("nvmf_subsystem_remove_listener", &NvmfSubsystemRemoveListenerParams{Nqn: params.Nqn, ListenAddress: addr}))
+		if err != nil || in.Listener.Spec.AnaState == bridge.NVMfAnaState_NVMF_ANA_STATE_UNSPECIFIED {
+			return err
+		}
+		ana := NvmfSubsystemListenerSetAnaStateParams{
+			Nqn:           params.Nqn,
+			ListenAddress: addr,
+			AnaState:      spdkAnaStates[in.Listener.Spec.AnaState],
+		}
+		var anaResult NvmfSubsystemListenerSetAnaStateResult
+		return tx.call("nvmf_subsystem_listener_set_ana_state", &ana, &anaResult, nil)
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("CreateNVMfListener: %s already exists", in.Listener.Spec.Id.Value)
+		return existing.(*bridge.NVMfListener), nil
+	}
+	log.Printf("Received from SPDK: %v", result)
+	return in.Listener, nil
+}
+
+func (s *server) DeleteNVMfListener(ctx context.Context, in *bridge.DeleteNVMfListenerRequest) (*emptypb.Empty, error) {
+	log.Printf("DeleteNVMfListener: Received from client: %v", in)
+	listener, ok := s.registry.listener(in.ListenerId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find listener %s", in.ListenerId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	subsys, ok := s.registry.subsystem(listener.Spec.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", listener.Spec.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	addr, err := listenAddress(listener.Spec)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	params := NvmfSubsystemRemoveListenerParams{
+		Nqn:           subsys.Spec.Nqn,
+		ListenAddress: addr,
+	}
+	var result NvmfSubsystemRemoveListenerResult
+	err = s.registry.listeners.remove(ctx, in.ListenerId.Value, func() error {
+		return call(ctx, "nvmf_subsystem_remove_listener", &params, &result)
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	return &emptypb.Empty{}, nil
+}
+
+// ListNVMfListener returns what SPDK has for the subsystem, including
+// listeners added behind the bridge's back, which have no ID
+func (s *server) ListNVMfListener(ctx context.Context, in *bridge.ListNVMfListenerRequest) (*bridge.ListNVMfListenerResponse, error) {
+	log.Printf("ListNVMfListener: Received from client: %v", in)
+	subsys, ok := s.registry.subsystem(in.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	result, err := getListeners(ctx, subsys.Spec.Nqn)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	addrs := make([]NvmfListenAddress, len(result))
+	for i := range result {
+		addrs[i] = result[i].Address
+	}
+	specs := s.listenerSpecs(in.SubsystemId.Value, addrs)
+	Blobarray := make([]*bridge.NVMfListener, len(result))
+	for i := range result {
+		Blobarray[i] = &bridge.NVMfListener{Spec: specs[i], Status: &bridge.NVMfListenerStatus{AnaState: anaState(&result[i])}}
+	}
+	return &bridge.ListNVMfListenerResponse{Listeners: Blobarray}, nil
+}
+
+// listenerSpecs returns the specs of the listeners SPDK has at addrs for
+// the subsystem, with the IDs and ANA states of those created through the
+// bridge
+func (s *server) listenerSpecs(subsystemID string, addrs []NvmfListenAddress) []*bridge.NVMfListenerSpec {
+	var known []*bridge.NVMfListener
+	for _, m := range s.registry.listeners.values() {
+		listener := m.(*bridge.NVMfListener)
+		if listener.Spec.SubsystemId.Value == subsystemID {
+			known = append(known, listener)
+		}
+	}
+	specs := make([]*bridge.NVMfListenerSpec, len(addrs))
+	for i, a := range addrs {
+		spec := listenerSpec(a)
+		spec.SubsystemId = &pc.ObjectKey{Value: subsystemID}
+		for _, listener := range known {
+			if addr, err := listenAddress(listener.Spec); err == nil && sameListenAddress(addr, a) {
+				spec.Id = listener.Spec.Id
+				spec.AnaState = listener.Spec.AnaState
+			}
+		}
+		specs[i] = spec
+	}
+	return specs
+}
+
+func (s *server) GetNVMfListener(ctx context.Context, in *bridge.GetNVMfListenerRequest) (*bridge.NVMfListener, error) {
+	log.Printf("GetNVMfListener: Received from client: %v", in)
+	listener, ok := s.registry.listener(in.ListenerId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find listener %s", in.ListenerId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	subsys, ok := s.registry.subsystem(listener.Spec.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", listener.Spec.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	addr, err := listenAddress(listener.Spec)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	result, err := getListeners(ctx, subsys.Spec.Nqn)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	for i := range result {
+		r := &result[i]
+		if sameListenAddress(addr, r.Address) {
+			return &bridge.NVMfListener{Spec: listener.Spec, Status: &bridge.NVMfListenerStatus{AnaState: anaState(r)}}, nil
+		}
+	}
+	msg := fmt.Sprintf("Could not find listener %s on %s", in.ListenerId.Value, subsys.Spec.Nqn)
+	log.Print(msg)
+	return nil, status.Errorf(codes.NotFound, msg)
+}
+
+// checkTransport fails with FailedPrecondition when SPDK has no transport
+// of type trtype to listen on
+func checkTransport(ctx context.Context, trtype string) error {
+	_, err := getTransport(ctx, trtype)
+	if status.Code(err) == codes.NotFound {
+		return status.Errorf(codes.FailedPrecondition, "no %s transport, create it first", trtype)
+	}
+	return err
+}
+
+func getListeners(ctx context.Context, nqn string) ([]NvmfSubsystemGetListenersResult, error) {
+	params := NvmfSubsystemGetListenersParams{Nqn: nqn}
+	var result []NvmfSubsystemGetListenersResult
+	err := call(ctx, "nvmf_subsystem_get_listeners", &params, &result)
+	return result, err
+}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+func testListener() *bridge.NVMfListener {
+	return &bridge.NVMfListener{Spec: &bridge.NVMfListenerSpec{
+		Id:          &pc.ObjectKey{Value: "listener-test"},
+		SubsystemId: &pc.ObjectKey{Value: "subsystem-test"},
+		Trtype:      pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		Adrfam:      pb.NvmeAddressFamily_NVMF_ADRFAM_IPV4,
+		Traddr:      "127.0.0.1",
+		Trsvcid:     "4420",
+	}}
+}
+
+func createTestListener(t *testing.T, c *bridgeClients) {
+	_, err := c.listener.CreateNVMfListener(context.Background(), &bridge.CreateNVMfListenerRequest{Listener: testListener()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListener_Create(t *testing.T) {
+	tests := map[string]struct {
+		change  func(l *bridge.NVMfListener)
+		spdkErr int
+		code    codes.Code
+	}{
+		"valid request":      {func(l *bridge.NVMfListener) {}, 0, codes.OK},
+		"unknown subsystem":  {func(l *bridge.NVMfListener) { l.Spec.SubsystemId.Value = "unknown" }, 0, codes.NotFound},
+		"unknown transport":  {func(l *bridge.NVMfListener) { l.Spec.Trtype = pb.NvmeTransportType_NVME_TRANSPORT_CUSTOM }, 0, codes.InvalidArgument},
+		"no transport":       {func(l *bridge.NVMfListener) { l.Spec.Trtype = pb.NvmeTransportType_NVME_TRANSPORT_RDMA }, 0, codes.FailedPrecondition},
+		"already listening":  {func(l *bridge.NVMfListener) {}, fakeEEXIST, codes.AlreadyExists},
+		"invalid parameters": {func(l *bridge.NVMfListener) {}, fakeInvalidParams, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			createTestSubsystem(t, c)
+			createTestTransport(t, c)
+			if tt.spdkErr != 0 {
+				spdk.setError("nvmf_subsystem_add_listener", tt.spdkErr, "failed")
+			}
+			listener := testListener()
+			tt.change(listener)
+			response, err := c.listener.CreateNVMfListener(context.Background(), &bridge.CreateNVMfListenerRequest{Listener: listener})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if err == nil && !proto.Equal(response, listener) {
+				t.Errorf("expected %v, got %v", listener, response)
+			}
+			if _, ok := c.server.registry.listener("listener-test"); ok != (err == nil) {
+				t.Errorf("listener recorded %v on error %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestListener_AnaState(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestTransport(t, c)
+	ctx := context.Background()
+	listener := testListener()
+	listener.Spec.AnaState = bridge.NVMfAnaState_NVMF_ANA_STATE_NON_OPTIMIZED
+	if _, err := c.listener.CreateNVMfListener(ctx, &bridge.CreateNVMfListenerRequest{Listener: listener}); err != nil {
+		t.Fatal(err)
+	}
+	response, err := c.listener.GetNVMfListener(ctx, &bridge.GetNVMfListenerRequest{ListenerId: listener.Spec.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Status.AnaState != bridge.NVMfAnaState_NVMF_ANA_STATE_NON_OPTIMIZED {
+		t.Errorf("unexpected status %v", response.Status)
+	}
+
+	// the listener is taken away again when SPDK refuses the ANA state
+	spdk.setError("nvmf_subsystem_listener_set_ana_state", fakeInvalidParams, "failed")
+	listener.Spec.Id.Value = "listener-ana"
+	listener.Spec.Trsvcid = "4421"
+	_, err = c.listener.CreateNVMfListener(ctx, &bridge.CreateNVMfListenerRequest{Listener: listener})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	spdk.mu.Lock()
+	left := len(spdk.subsystems[testNqn].listeners)
+	spdk.mu.Unlock()
+	if left != 1 {
+		t.Errorf("expected the failed listener removed, %d left", left)
+	}
+}
+
+func TestListener_List(t *testing.T) {
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestTransport(t, c)
+	createTestListener(t, c)
+	ctx := context.Background()
+	// added behind the bridge's back
+	if err := call(ctx, "nvmf_create_transport", &NvmfCreateTransportParams{Trtype: "RDMA"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	params := NvmfSubsystemAddListenerParams{Nqn: testNqn, ListenAddress: NvmfListenAddress{Trtype: "RDMA", Adrfam: "IPv4", Traddr: "10.0.0.1", Trsvcid: "4420"}}
+	if err := call(ctx, "nvmf_subsystem_add_listener", &params, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := c.listener.ListNVMfListener(ctx, &bridge.ListNVMfListenerRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Listeners) != 2 {
+		t.Fatalf("unexpected listeners %v", response.Listeners)
+	}
+	if !proto.Equal(response.Listeners[0].Spec, testListener().Spec) {
+		t.Errorf("expected %v, got %v", testListener().Spec, response.Listeners[0].Spec)
+	}
+	unmanaged := response.Listeners[1].Spec
+	if unmanaged.Id != nil || unmanaged.Trtype != pb.NvmeTransportType_NVME_TRANSPORT_RDMA || unmanaged.Traddr != "10.0.0.1" {
+		t.Errorf("unexpected listener %v", unmanaged)
+	}
+	if response.Listeners[1].Status.AnaState != bridge.NVMfAnaState_NVMF_ANA_STATE_OPTIMIZED {
+		t.Errorf("unexpected status %v", response.Listeners[1].Status)
+	}
+
+	_, err = c.listener.ListNVMfListener(ctx, &bridge.ListNVMfListenerRequest{SubsystemId: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestListener_Delete(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestTransport(t, c)
+	createTestListener(t, c)
+	ctx := context.Background()
+
+	spdk.failNext("nvmf_subsystem_remove_listener", fakeInvalidParams, "failed")
+	_, err := c.listener.DeleteNVMfListener(ctx, &bridge.DeleteNVMfListenerRequest{ListenerId: &pc.ObjectKey{Value: "listener-test"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if _, ok := c.server.registry.listener("listener-test"); !ok {
+		t.Error("listener forgotten although SPDK still has it")
+	}
+
+	if _, err := c.listener.DeleteNVMfListener(ctx, &bridge.DeleteNVMfListenerRequest{ListenerId: &pc.ObjectKey{Value: "listener-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.listener.GetNVMfListener(ctx, &bridge.GetNVMfListenerRequest{ListenerId: &pc.ObjectKey{Value: "listener-test"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for deleted listener, got %v", err)
+	}
+	_, err = c.listener.DeleteNVMfListener(ctx, &bridge.DeleteNVMfListenerRequest{ListenerId: &pc.ObjectKey{Value: "listener-test"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for deleted listener, got %v", err)
+	}
+	spdk.mu.Lock()
+	left := len(spdk.subsystems[testNqn].listeners)
+	spdk.mu.Unlock()
+	if left != 0 {
+		t.Errorf("listener left in SPDK")
+	}
+}
+
+// a listener removed behind the bridge's back is missing
+func TestListener_Get(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestTransport(t, c)
+	createTestListener(t, c)
+	spdk.mu.Lock()
+	spdk.subsystems[testNqn].listeners = nil
+	spdk.mu.Unlock()
+	_, err := c.listener.GetNVMfListener(context.Background(), &bridge.GetNVMfListenerRequest{ListenerId: &pc.ObjectKey{Value: "listener-test"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metrics of the bridge itself, shared by every registry serving them
+var (
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "opi_bridge",
+		Name:      "grpc_requests_total",
+		Help:      "gRPC requests handled, by method and status code.",
+	}, []string{"method", "code"})
+	grpcRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "opi_bridge",
+		Name:      "grpc_request_duration_seconds",
+		Help:      "Time gRPC requests took, streams until they ended.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+	spdkCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "opi_bridge",
+		Name:      "spdk_calls_total",
+		Help:      "JSON RPC calls sent to SPDK, by method.",
+	}, []string{"method"})
+	spdkCallErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "opi_bridge",
+		Name:      "spdk_call_errors_total",
+		Help:      "JSON RPC calls to SPDK that failed, by method and the gRPC code of the error.",
+	}, []string{"method", "code"})
+	spdkCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "opi_bridge",
+		Name:      "spdk_call_duration_seconds",
+		Help:      "Time JSON RPC calls to SPDK took, by method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+	spdkConsecutiveFailures = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "opi_bridge",
+		Name:      "spdk_consecutive_failures",
+		Help:      "JSON RPC calls that could not reach SPDK since the last one that did.",
+	}, func() float64 { return float64(atomic.LoadUint64(&rpcConsecutiveFailures)) })
+)
+
+// newMetricsHandler serves the metrics of the bridge, and those of SPDK
+// scraped on every request
+func newMetricsHandler(srv *server) http.Handler {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		grpcRequests, grpcRequestDuration,
+		spdkCalls, spdkCallErrors, spdkCallDuration, spdkConsecutiveFailures,
+		newSpdkCollector(srv.registry),
+	)
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{})
+}
+
+func observeGrpcRequest(method string, start time.Time, err error) {
+	grpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	grpcRequestDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func metricsUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	observeGrpcRequest(info.FullMethod, start, err)
+	return resp, err
+}
+
+func metricsStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	observeGrpcRequest(info.FullMethod, start, err)
+	return err
+}
+
+func observeSpdkCall(method string, start time.Time, err error) {
+	spdkCalls.WithLabelValues(method).Inc()
+	if err != nil {
+		spdkCallErrors.WithLabelValues(method, status.Code(err).String()).Inc()
+	}
+	spdkCallDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// spdkCollector scrapes the I/O counters of the bdevs and the queue pairs
+// of the NVMe-oF poll groups from SPDK. Bdevs are labelled with the
+// namespace and subsystem they back, if any, besides their own name, which
+// is the volume ID in the OPI APIs.
+type spdkCollector struct {
+	registry *registry
+
+	up               *prometheus.Desc
+	bdevBytes        *prometheus.Desc
+	bdevOperations   *prometheus.Desc
+	bdevLatency      *prometheus.Desc
+	pollGroupQpairs  *prometheus.Desc
+	pollGroupCurrent *prometheus.Desc
+	pollGroupBdevIo  *prometheus.Desc
+}
+
+func newSpdkCollector(r *registry) *spdkCollector {
+	bdev := []string{"volume_id", "namespace_id", "subsystem_id", "operation"}
+	group := []string{"poll_group"}
+	qpairs := []string{"poll_group", "type"}
+	return &spdkCollector{
+		registry:       r,
+		up:             prometheus.NewDesc("opi_bridge_spdk_up", "Whether the last scrape of SPDK succeeded.", nil, nil),
+		bdevBytes:      prometheus.NewDesc("spdk_bdev_bytes_total", "Bytes a bdev read, wrote or unmapped.", bdev, nil),
+		bdevOperations: prometheus.NewDesc("spdk_bdev_operations_total", "Read, write or unmap operations of a bdev.", bdev, nil),
+		bdevLatency:    prometheus.NewDesc("spdk_bdev_latency_seconds_total", "Total time the read, write or unmap operations of a bdev took.", bdev, nil),
+		pollGroupQpairs: prometheus.NewDesc("spdk_nvmf_poll_group_qpairs_total",
+			"Admin or I/O queue pairs a poll group has served since SPDK started.", qpairs, nil),
+		pollGroupCurrent: prometheus.NewDesc("spdk_nvmf_poll_group_qpairs",
+			"Admin or I/O queue pairs a poll group serves.", qpairs, nil),
+		pollGroupBdevIo: prometheus.NewDesc("spdk_nvmf_poll_group_pending_bdev_io",
+			"Bdev I/O a poll group waits for.", group, nil),
+	}
+}
+
+func (c *spdkCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.bdevBytes
+	ch <- c.bdevOperations
+	ch <- c.bdevLatency
+	ch <- c.pollGroupQpairs
+	ch <- c.pollGroupCurrent
+	ch <- c.pollGroupBdevIo
+}
+
+func (c *spdkCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	up := 1.0
+	var iostat BdevGetIostatResult
+	if err := call(ctx, "bdev_get_iostat", &BdevGetIostatParams{}, &iostat); err != nil {
+		log.Printf("error: %v", err)
+		up = 0
+	} else {
+		c.collectBdevs(ch, sampleBdevs(iostat))
+	}
+	var stats NvmfGetSubsystemStatsResult
+	if err := call(ctx, "nvmf_get_stats", nil, &stats); err != nil {
+		log.Printf("error: %v", err)
+		up = 0
+	} else {
+		c.collectPollGroups(ch, pollGroups(stats))
+	}
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, up)
+}
+
+func (c *spdkCollector) collectBdevs(ch chan<- prometheus.Metric, bdevs map[string]volumeSample) {
+	namespaces := map[string]*pb.NVMeNamespace{}
+	for _, m := range c.registry.namespaces.values() {
+		namespace := m.(*pb.NVMeNamespace)
+		namespaces[namespace.Spec.GetVolumeId().GetValue()] = namespace
+	}
+	for name, sample := range bdevs {
+		namespace := namespaces[name]
+		labels := []string{name, namespace.GetSpec().GetId().GetValue(), namespace.GetSpec().GetSubsystemId().GetValue()}
+		for _, op := range []struct {
+			name     string
+			counters ioCounters
+		}{{"read", sample.read}, {"write", sample.write}, {"unmap", sample.unmap}} {
+			values := append(labels[:3:3], op.name)
+			ch <- prometheus.MustNewConstMetric(c.bdevBytes, prometheus.CounterValue, float64(op.counters.bytes), values...)
+			ch <- prometheus.MustNewConstMetric(c.bdevOperations, prometheus.CounterValue, float64(op.counters.ops), values...)
+			ch <- prometheus.MustNewConstMetric(c.bdevLatency, prometheus.CounterValue,
+				ticksToMicroseconds(op.counters.latencyTicks, sample.tickRate)/1e6, values...)
+		}
+	}
+}
+
+func (c *spdkCollector) collectPollGroups(ch chan<- prometheus.Metric, groups []pollGroupStats) {
+	for _, g := range groups {
+		ch <- prometheus.MustNewConstMetric(c.pollGroupQpairs, prometheus.CounterValue, float64(g.AdminQpairs), g.Name, "admin")
+		ch <- prometheus.MustNewConstMetric(c.pollGroupQpairs, prometheus.CounterValue, float64(g.IoQpairs), g.Name, "io")
+		ch <- prometheus.MustNewConstMetric(c.pollGroupCurrent, prometheus.GaugeValue, float64(g.CurrentAdminQpairs), g.Name, "admin")
+		ch <- prometheus.MustNewConstMetric(c.pollGroupCurrent, prometheus.GaugeValue, float64(g.CurrentIoQpairs), g.Name, "io")
+		ch <- prometheus.MustNewConstMetric(c.pollGroupBdevIo, prometheus.GaugeValue, float64(g.PendingBdevIo), g.Name)
+	}
+}
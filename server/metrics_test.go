@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+)
+
+// scrapeMetrics returns the metrics the bridge serves, in the text format
+func scrapeMetrics(t *testing.T, c *bridgeClients) string {
+	t.Helper()
+	recorder := httptest.NewRecorder()
+	newMetricsHandler(c.server).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", recorder.Code)
+	}
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func TestMetrics_Bridge(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	createTestSubsystem(t, c)
+	spdk.setError("bdev_null_create", fakeInvalidParams, "Invalid parameters")
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}}); err == nil {
+		t.Fatal("expected the create to fail")
+	}
+
+	metrics := scrapeMetrics(t, c)
+	for _, line := range []string{
+		`opi_bridge_grpc_requests_total{code="OK",method="/opi_api.storage.v1.FrontendNvmeService/CreateNVMeSubsystem"}`,
+		`opi_bridge_grpc_requests_total{code="InvalidArgument",method="/opi_api.storage.v1.NullDebugService/NullDebugCreate"}`,
+		`opi_bridge_grpc_request_duration_seconds_count{method="/opi_api.storage.v1.FrontendNvmeService/CreateNVMeSubsystem"}`,
+		`opi_bridge_spdk_calls_total{method="nvmf_create_subsystem"}`,
+		`opi_bridge_spdk_call_errors_total{code="InvalidArgument",method="bdev_null_create"}`,
+		`opi_bridge_spdk_call_duration_seconds_count{method="nvmf_create_subsystem"}`,
+		"opi_bridge_spdk_up 1",
+	} {
+		if !strings.Contains(metrics, line) {
+			t.Errorf("expected %s in\n%s", line, metrics)
+		}
+	}
+}
+
+func TestMetrics_Spdk(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestNamespace(t, c)
+	createTestController(t, c)
+	connectTestQpairs(spdk)
+	spdk.mu.Lock()
+	b := spdk.bdevs["Malloc1"]
+	b.bytesRead, b.readOps, b.readLatencyTicks = 8192, 2, 5000
+	spdk.mu.Unlock()
+
+	metrics := scrapeMetrics(t, c)
+	for _, line := range []string{
+		`spdk_bdev_bytes_total{namespace_id="namespace-test",operation="read",subsystem_id="subsystem-test",volume_id="Malloc1"} 8192`,
+		`spdk_bdev_operations_total{namespace_id="namespace-test",operation="read",subsystem_id="subsystem-test",volume_id="Malloc1"} 2`,
+		`spdk_bdev_latency_seconds_total{namespace_id="namespace-test",operation="read",subsystem_id="subsystem-test",volume_id="Malloc1"} 2.5e-06`,
+		`spdk_bdev_bytes_total{namespace_id="",operation="write",subsystem_id="",volume_id="Malloc0"} 0`,
+		`spdk_nvmf_poll_group_qpairs{poll_group="nvmf_tgt_poll_group_0",type="admin"} 2`,
+		`spdk_nvmf_poll_group_qpairs_total{poll_group="nvmf_tgt_poll_group_0",type="io"} 1`,
+		`spdk_nvmf_poll_group_pending_bdev_io{poll_group="nvmf_tgt_poll_group_0"} 0`,
+	} {
+		if !strings.Contains(metrics, line) {
+			t.Errorf("expected %s in\n%s", line, metrics)
+		}
+	}
+
+	// the bridge's metrics are served when SPDK fails
+	spdk.setError("nvmf_get_stats", fakeInvalidParams, "failed")
+	metrics = scrapeMetrics(t, c)
+	if !strings.Contains(metrics, "opi_bridge_spdk_up 0") || strings.Contains(metrics, "spdk_nvmf_poll_group_qpairs") ||
+		!strings.Contains(metrics, "spdk_bdev_bytes_total") {
+		t.Errorf("unexpected metrics\n%s", metrics)
+	}
+}
@@ -19,22 +19,37 @@ import (
 
 //////////////////////////////////////////////////////////
 
-func (s *server) CreateCrypto(ctx context.Context, in *pb.CreateCryptoRequest) (*pb.Crypto, error) {
-	log.Printf("CreateCrypto: Received from client: %v", in)
-	params := BdevCryptoCreateParams{
-		Name:         in.Volume.CryptoId.Value,
-		BaseBdevName: in.Volume.VolumeId.Value,
+// cryptoCreateParams creates the SPDK crypto bdev behind an OPI volume
+func cryptoCreateParams(volume *pb.Crypto) *BdevCryptoCreateParams {
+	// TODO: use volume.Cipher.String()
+	return &BdevCryptoCreateParams{
+		Name:         volume.CryptoId.Value,
+		BaseBdevName: volume.VolumeId.Value,
 		CryptoPmd:    "crypto_aesni_mb",
-		Key:          string(in.Volume.Key),
+		Key:          string(volume.Key),
 		Cipher:       "AES_CBC",
 	}
-	// TODO: use in.Volume.Cipher.String()
+}
+
+func (s *server) CreateCrypto(ctx context.Context, in *pb.CreateCryptoRequest) (*pb.Crypto, error) {
+	log.Printf("CreateCrypto: Received from client: %v", in)
+	params := cryptoCreateParams(in.Volume)
+	tx := newSaga(ctx)
+	defer tx.rollback()
 	var result BdevCryptoCreateResult
-	err := call("bdev_crypto_create", &params, &result)
+	existing, err := s.registry.cryptos.create(ctx, in.Volume.CryptoId.Value, in.Volume, recreating(ctx), func() error {
+		return tx.call("bdev_crypto_create", params, &result,
+			undoCall("bdev_crypto_delete", &BdevCryptoDeleteParams{Name: params.Name}))
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
+	tx.commit()
+	if existing != nil {
+		log.Printf("CreateCrypto: %s already exists", in.Volume.CryptoId.Value)
+		return existing.(*pb.Crypto), nil
+	}
 	log.Printf("Received from SPDK: %v", result)
 	response := &pb.Crypto{}
 	err = deepcopier.Copy(in.Volume).To(response)
@@ -51,7 +66,9 @@ func (s *server) DeleteCrypto(ctx context.Context, in *pb.DeleteCryptoRequest) (
 		Name: in.CryptoId.Value,
 	}
 	var result BdevCryptoDeleteResult
-	err := call("bdev_crypto_delete", &params, &result)
+	err := s.registry.cryptos.remove(ctx, in.CryptoId.Value, func() error {
+		return call(ctx, "bdev_crypto_delete", &params, &result)
+	})
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -65,39 +82,49 @@ func (s *server) DeleteCrypto(ctx context.Context, in *pb.DeleteCryptoRequest) (
 
 func (s *server) UpdateCrypto(ctx context.Context, in *pb.UpdateCryptoRequest) (*pb.Crypto, error) {
 	log.Printf("UpdateCrypto: Received from client: %v", in)
-	params1 := BdevCryptoDeleteParams{
-		Name: in.Volume.CryptoId.Value,
-	}
-	var result1 BdevCryptoDeleteResult
-	err1 := call("bdev_crypto_delete", &params1, &result1)
-	if err1 != nil {
-		log.Printf("error: %v", err1)
-		return nil, err1
-	}
-	log.Printf("Received from SPDK: %v", result1)
-	if !result1 {
-		log.Printf("Could not delete: %v", in)
-	}
-	params2 := BdevCryptoCreateParams{
-		Name:         in.Volume.CryptoId.Value,
-		BaseBdevName: in.Volume.VolumeId.Value,
-		CryptoPmd:    "crypto_aesni_mb",
-		Key:          string(in.Volume.Key),
-		Cipher:       "AES_CBC",
-	}
-	// TODO: use in.Volume.Cipher.String()
-	var result2 BdevCryptoCreateResult
-	err2 := call("bdev_crypto_create", &params2, &result2)
-	if err2 != nil {
-		log.Printf("error: %v", err2)
-		return nil, err2
+	// the volume is deleted and created again, keep what it was before
+	var restore func(ctx context.Context) error
+	if old, ok := s.registry.cryptos.load(in.Volume.CryptoId.Value); ok && len(old.(*pb.Crypto).Key) != 0 {
+		restore = undoCall("bdev_crypto_create", cryptoCreateParams(old.(*pb.Crypto)))
+	} else if ok {
+		log.Printf("UpdateCrypto: the key of %s is not kept across restarts, it cannot be restored if the update fails", in.Volume.CryptoId.Value)
+	} else {
+		log.Printf("UpdateCrypto: %s is unknown and cannot be restored if the update fails", in.Volume.CryptoId.Value)
+	}
+	tx := newSaga(ctx)
+	defer tx.rollback()
+	err := s.registry.cryptos.put(ctx, in.Volume.CryptoId.Value, in.Volume, func() error {
+		params1 := BdevCryptoDeleteParams{
+			Name: in.Volume.CryptoId.Value,
+		}
+		var result1 BdevCryptoDeleteResult
+		err1 := tx.call("bdev_crypto_delete", &params1, &result1, restore)
+		if err1 != nil {
+			return err1
+		}
+		log.Printf("Received from SPDK: %v", result1)
+		if !result1 {
+			log.Printf("Could not delete: %v", in)
+		}
+		params2 := cryptoCreateParams(in.Volume)
+		var result2 BdevCryptoCreateResult
+		err2 := tx.call("bdev_crypto_create", params2, &result2, undoCall("bdev_crypto_delete", &params1))
+		if err2 != nil {
+			return err2
+		}
+		log.Printf("Received from SPDK: %v", result2)
+		return nil
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result2)
+	tx.commit()
 	response := &pb.Crypto{}
-	err3 := deepcopier.Copy(in.Volume).To(response)
-	if err3 != nil {
-		log.Printf("error: %v", err3)
-		return nil, err3
+	err = deepcopier.Copy(in.Volume).To(response)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
 	return response, nil
 }
@@ -105,7 +132,7 @@ func (s *server) UpdateCrypto(ctx context.Context, in *pb.UpdateCryptoRequest) (
 func (s *server) ListCrypto(ctx context.Context, in *pb.ListCryptoRequest) (*pb.ListCryptoResponse, error) {
 	log.Printf("ListCrypto: Received from client: %v", in)
 	var result []BdevGetBdevsResult
-	err := call("bdev_get_bdevs", nil, &result)
+	err := call(ctx, "bdev_get_bdevs", nil, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -125,7 +152,7 @@ func (s *server) GetCrypto(ctx context.Context, in *pb.GetCryptoRequest) (*pb.Cr
 		Name: in.CryptoId.Value,
 	}
 	var result []BdevGetBdevsResult
-	err := call("bdev_get_bdevs", &params, &result)
+	err := call(ctx, "bdev_get_bdevs", &params, &result)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
@@ -141,23 +168,17 @@ func (s *server) GetCrypto(ctx context.Context, in *pb.GetCryptoRequest) (*pb.Cr
 
 func (s *server) CryptoStats(ctx context.Context, in *pb.CryptoStatsRequest) (*pb.CryptoStatsResponse, error) {
 	log.Printf("CryptoStats: Received from client: %v", in)
-	params := BdevGetIostatParams{
-		Name: in.CryptoId.Value,
-	}
-	// See https://mholt.github.io/json-to-go/
-	var result BdevGetIostatResult
-	err := call("bdev_get_iostat", &params, &result)
+	stats, err := getVolumeStats(ctx, in.CryptoId.Value, nil)
 	if err != nil {
 		log.Printf("error: %v", err)
 		return nil, err
 	}
-	log.Printf("Received from SPDK: %v", result)
-	if len(result.Bdevs) != 1 {
-		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
-		log.Print(msg)
-		return nil, status.Errorf(codes.InvalidArgument, msg)
+	encoded, err := encodeVolumeStats(stats)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
 	}
-	return &pb.CryptoStatsResponse{Stats: fmt.Sprint(result.Bdevs[0])}, nil
+	return &pb.CryptoStatsResponse{CryptoId: in.CryptoId, Stats: encoded}, nil
 }
 
 //////////////////////////////////////////////////////////
@@ -1,9 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
 package main
 
 import (
+	"context"
 	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+func testCrypto() *pb.Crypto {
+	return &pb.Crypto{
+		CryptoId: &pc.ObjectKey{Value: "Crypto42"},
+		VolumeId: &pc.ObjectKey{Value: "Malloc0"},
+		Key:      []byte("0123456789abcdef0123456789abcdef"),
+	}
+}
+
 func TestMiddleEnd_Crypto(t *testing.T) {
+	_, c := startBridge(t)
+	ctx := context.Background()
+
+	if _, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Fatal(err)
+	}
+	// a retried create is answered from the registry
+	if _, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+	other := testCrypto()
+	other.VolumeId.Value = "Malloc1"
+	_, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: other})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	if _, err := c.middleend.UpdateCrypto(ctx, &pb.UpdateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Fatal(err)
+	}
+	list, err := c.middleend.ListCrypto(ctx, &pb.ListCryptoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Volumes) != 3 {
+		t.Errorf("unexpected volumes %v", list.Volumes)
+	}
+	get, err := c.middleend.GetCrypto(ctx, &pb.GetCryptoRequest{CryptoId: &pc.ObjectKey{Value: "Crypto42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.CryptoId.Value != "Crypto42" {
+		t.Errorf("unexpected volume %v", get)
+	}
+	if _, err := c.middleend.CryptoStats(ctx, &pb.CryptoStatsRequest{CryptoId: &pc.ObjectKey{Value: "Crypto42"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.middleend.DeleteCrypto(ctx, &pb.DeleteCryptoRequest{CryptoId: &pc.ObjectKey{Value: "Crypto42"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.middleend.GetCrypto(ctx, &pb.GetCryptoRequest{CryptoId: &pc.ObjectKey{Value: "Crypto42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
 
+func TestMiddleEnd_CreateCrypto(t *testing.T) {
+	tests := map[string]struct {
+		volume  string
+		spdkErr int
+		code    codes.Code
+	}{
+		"valid request":    {"Malloc0", 0, codes.OK},
+		"missing base":     {"Malloc9", 0, codes.NotFound},
+		"no crypto device": {"Malloc0", -12, codes.ResourceExhausted},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			if tt.spdkErr != 0 {
+				spdk.setError("bdev_crypto_create", tt.spdkErr, "failed")
+			}
+			crypto := testCrypto()
+			crypto.VolumeId.Value = tt.volume
+			_, err := c.middleend.CreateCrypto(context.Background(), &pb.CreateCryptoRequest{Volume: crypto})
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
 }
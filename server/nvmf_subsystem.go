@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"log"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// maxCntlid is the highest controller ID SPDK assigns
+const maxCntlid = 65519
+
+func (s *server) CreateNVMfSubsystem(ctx context.Context, in *bridge.CreateNVMfSubsystemRequest) (*bridge.NVMfSubsystem, error) {
+	log.Printf("CreateNVMfSubsystem: Received from client: %v", in)
+	if in.Subsystem.GetSubsystem().GetSpec().GetId().GetValue() == "" {
+		err := status.Error(codes.InvalidArgument, "missing subsystem ID")
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	min, max := in.Subsystem.MinCntlid, in.Subsystem.MaxCntlid
+	if (min != 0 || max != 0) && (min < 1 || min > max || max > maxCntlid) {
+		err := status.Errorf(codes.InvalidArgument, "invalid controller ID range %d-%d, expected 1-%d", min, max, maxCntlid)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	id := in.Subsystem.Subsystem.Spec.Id.Value
+	cntlids := &bridge.NVMfSubsystem{MinCntlid: min, MaxCntlid: max}
+	var subsys *pb.NVMeSubsystem
+	err := s.registry.cntlidRanges.put(ctx, id, cntlids, func() error {
+		// the range of a subsystem only changes by recreating it
+		if _, ok := s.registry.subsystem(id); ok && !recreating(ctx) && !proto.Equal(s.registry.cntlidRange(id), cntlids) {
+			return status.Errorf(codes.AlreadyExists, "subsystem %s already exists with a different controller ID range", id)
+		}
+		var err error
+		subsys, err = s.createNVMeSubsystem(ctx, in.Subsystem.Subsystem, cntlids)
+		return err
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return &bridge.NVMfSubsystem{Subsystem: subsys, MinCntlid: min, MaxCntlid: max}, nil
+}
+
+func (s *server) GetNVMfSubsystem(ctx context.Context, in *bridge.GetNVMfSubsystemRequest) (*bridge.NVMfSubsystem, error) {
+	log.Printf("GetNVMfSubsystem: Received from client: %v", in)
+	subsys, ok := s.registry.subsystem(in.SubsystemId.GetValue())
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", in.SubsystemId.GetValue())
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	result, err := getSubsystem(ctx, subsys.Spec.Nqn)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	spec := subsystemSpec(result)
+	spec.Id = subsys.Spec.Id
+	return &bridge.NVMfSubsystem{
+		Subsystem: &pb.NVMeSubsystem{Spec: spec},
+		MinCntlid: int32(result.MinCntlid),
+		MaxCntlid: int32(result.MaxCntlid),
+		Listeners: s.listenerSpecs(subsys.Spec.Id.Value, result.ListenAddresses),
+		Access:    accessOf(subsys, result),
+	}, nil
+}
@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+func testNVMfSubsystem() *bridge.NVMfSubsystem {
+	return &bridge.NVMfSubsystem{Subsystem: testSubsystem(), MinCntlid: 10, MaxCntlid: 20}
+}
+
+func TestNVMfSubsystem_Create(t *testing.T) {
+	tests := map[string]struct {
+		min, max int32
+		code     codes.Code
+		wantMin  int
+		wantMax  int
+	}{
+		"range":         {10, 20, codes.OK, 10, 20},
+		"single":        {7, 7, codes.OK, 7, 7},
+		"default range": {0, 0, codes.OK, 1, maxCntlid},
+		"missing min":   {0, 20, codes.InvalidArgument, 0, 0},
+		"reversed":      {20, 10, codes.InvalidArgument, 0, 0},
+		"too high":      {10, maxCntlid + 1, codes.InvalidArgument, 0, 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			ctx := context.Background()
+			subsys := &bridge.NVMfSubsystem{Subsystem: testSubsystem(), MinCntlid: tt.min, MaxCntlid: tt.max}
+			response, err := c.subsystem.CreateNVMfSubsystem(ctx, &bridge.CreateNVMfSubsystemRequest{Subsystem: subsys})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			spdk.mu.Lock()
+			s, ok := spdk.subsystems[testNqn]
+			spdk.mu.Unlock()
+			if ok != (err == nil) {
+				t.Fatalf("subsystem created %v on error %v", ok, err)
+			}
+			if err != nil {
+				return
+			}
+			if !proto.Equal(response, subsys) {
+				t.Errorf("expected %v, got %v", subsys, response)
+			}
+			if s.minCntlid != tt.wantMin || s.maxCntlid != tt.wantMax {
+				t.Errorf("expected controller IDs %d-%d, got %d-%d", tt.wantMin, tt.wantMax, s.minCntlid, s.maxCntlid)
+			}
+
+			got, err := c.subsystem.GetNVMfSubsystem(ctx, &bridge.GetNVMfSubsystemRequest{SubsystemId: subsys.Subsystem.Spec.Id})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.MinCntlid != int32(tt.wantMin) || got.MaxCntlid != int32(tt.wantMax) || !proto.Equal(got.Subsystem, subsys.Subsystem) {
+				t.Errorf("unexpected subsystem %v", got)
+			}
+		})
+	}
+}
+
+func TestNVMfSubsystem_CreateExisting(t *testing.T) {
+	_, c := startBridge(t)
+	ctx := context.Background()
+	subsys := testNVMfSubsystem()
+	if _, err := c.subsystem.CreateNVMfSubsystem(ctx, &bridge.CreateNVMfSubsystemRequest{Subsystem: subsys}); err != nil {
+		t.Fatal(err)
+	}
+	response, err := c.subsystem.CreateNVMfSubsystem(ctx, &bridge.CreateNVMfSubsystemRequest{Subsystem: subsys})
+	if err != nil || !proto.Equal(response, subsys) {
+		t.Errorf("expected the same subsystem again, got %v, %v", response, err)
+	}
+	if _, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: testSubsystem()}); err != nil {
+		t.Errorf("expected the same subsystem again, got %v", err)
+	}
+
+	other := testNVMfSubsystem()
+	other.MaxCntlid = 30
+	if _, err := c.subsystem.CreateNVMfSubsystem(ctx, &bridge.CreateNVMfSubsystemRequest{Subsystem: other}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists for another range, got %v", err)
+	}
+	if got := c.server.registry.cntlidRange("subsystem-test"); got.MinCntlid != 10 || got.MaxCntlid != 20 {
+		t.Errorf("expected the range kept, got %v", got)
+	}
+
+	// the range goes with the subsystem
+	if _, err := c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: subsys.Subsystem.Spec.Id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.server.registry.cntlidRanges.load("subsystem-test"); ok {
+		t.Error("expected the range removed with the subsystem")
+	}
+	if _, err := c.subsystem.CreateNVMfSubsystem(ctx, &bridge.CreateNVMfSubsystemRequest{Subsystem: other}); err != nil {
+		t.Errorf("expected the subsystem recreated with another range, got %v", err)
+	}
+}
+
+func TestNVMfSubsystem_DefaultSerialNumber(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	serials := map[string]bool{}
+	for _, nqn := range []string{"nqn.2022-09.io.spdk:opi1", "nqn.2022-09.io.spdk:opi2"} {
+		subsys := &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{Id: &pc.ObjectKey{Value: nqn}, Nqn: nqn}}
+		response, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: subsys})
+		if err != nil {
+			t.Fatal(err)
+		}
+		spdk.mu.Lock()
+		serial := spdk.subsystems[nqn].serialNumber
+		spdk.mu.Unlock()
+		if len(serial) != 20 || serial != response.Spec.SerialNumber || serials[serial] {
+			t.Errorf("expected a distinct serial number of 20 characters, got %q, response %v", serial, response)
+		}
+		serials[serial] = true
+
+		// a retry without a serial number is the same subsystem
+		if _, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: subsys}); err != nil {
+			t.Errorf("expected the same subsystem again, got %v", err)
+		}
+	}
+}
+
+func TestNVMfSubsystem_Recreate(t *testing.T) {
+	_, c := startBridge(t)
+	ctx := context.Background()
+	if _, err := c.subsystem.CreateNVMfSubsystem(ctx, &bridge.CreateNVMfSubsystemRequest{Subsystem: testNVMfSubsystem()}); err != nil {
+		t.Fatal(err)
+	}
+	// SPDK comes back empty
+	spdk := newFakeSpdk(t)
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.reconcile(ctx)
+	spdk.mu.Lock()
+	defer spdk.mu.Unlock()
+	s, ok := spdk.subsystems[testNqn]
+	if !ok || s.minCntlid != 10 || s.maxCntlid != 20 {
+		t.Errorf("expected the subsystem recreated with its controller IDs, got %+v", s)
+	}
+}
+
+func TestNVMfSubsystem_Listeners(t *testing.T) {
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestTransport(t, c)
+	createTestListener(t, c)
+	got, err := c.subsystem.GetNVMfSubsystem(context.Background(), &bridge.GetNVMfSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Listeners) != 1 || !proto.Equal(got.Listeners[0], testListener().Spec) {
+		t.Errorf("expected listener %v, got %v", testListener().Spec, got.Listeners)
+	}
+}
+
+func TestNVMfSubsystem_Access(t *testing.T) {
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestHost(t, c)
+	restrictTestSubsystem(t, c)
+	got, err := c.subsystem.GetNVMfSubsystem(context.Background(), &bridge.GetNVMfSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &bridge.NVMfSubsystemAccess{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}, HostNqns: []string{testHostNqn}}
+	if !proto.Equal(got.Access, want) {
+		t.Errorf("expected access %v, got %v", want, got.Access)
+	}
+}
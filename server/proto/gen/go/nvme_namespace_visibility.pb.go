@@ -0,0 +1,350 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: nvme_namespace_visibility.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/common/v1/gen/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NVMeNamespaceVisibility struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NamespaceId *_go.ObjectKey `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+	// controllers of the namespace's subsystem that see it
+	ControllerIds []*_go.ObjectKey `protobuf:"bytes,2,rep,name=controller_ids,json=controllerIds,proto3" json:"controller_ids,omitempty"`
+	// NQNs of further hosts that see it
+	HostNqns []string `protobuf:"bytes,3,rep,name=host_nqns,json=hostNqns,proto3" json:"host_nqns,omitempty"`
+	// every host sees the namespace, output only
+	AnyHost bool `protobuf:"varint,4,opt,name=any_host,json=anyHost,proto3" json:"any_host,omitempty"`
+}
+
+func (x *NVMeNamespaceVisibility) Reset() {
+	*x = NVMeNamespaceVisibility{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvme_namespace_visibility_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMeNamespaceVisibility) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMeNamespaceVisibility) ProtoMessage() {}
+
+func (x *NVMeNamespaceVisibility) ProtoReflect() protoreflect.Message {
+	mi := &file_nvme_namespace_visibility_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMeNamespaceVisibility.ProtoReflect.Descriptor instead.
+func (*NVMeNamespaceVisibility) Descriptor() ([]byte, []int) {
+	return file_nvme_namespace_visibility_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NVMeNamespaceVisibility) GetNamespaceId() *_go.ObjectKey {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+func (x *NVMeNamespaceVisibility) GetControllerIds() []*_go.ObjectKey {
+	if x != nil {
+		return x.ControllerIds
+	}
+	return nil
+}
+
+func (x *NVMeNamespaceVisibility) GetHostNqns() []string {
+	if x != nil {
+		return x.HostNqns
+	}
+	return nil
+}
+
+func (x *NVMeNamespaceVisibility) GetAnyHost() bool {
+	if x != nil {
+		return x.AnyHost
+	}
+	return false
+}
+
+type UpdateNVMeNamespaceVisibilityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Visibility *NVMeNamespaceVisibility `protobuf:"bytes,1,opt,name=visibility,proto3" json:"visibility,omitempty"`
+}
+
+func (x *UpdateNVMeNamespaceVisibilityRequest) Reset() {
+	*x = UpdateNVMeNamespaceVisibilityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvme_namespace_visibility_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateNVMeNamespaceVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNVMeNamespaceVisibilityRequest) ProtoMessage() {}
+
+func (x *UpdateNVMeNamespaceVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvme_namespace_visibility_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNVMeNamespaceVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNVMeNamespaceVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_nvme_namespace_visibility_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UpdateNVMeNamespaceVisibilityRequest) GetVisibility() *NVMeNamespaceVisibility {
+	if x != nil {
+		return x.Visibility
+	}
+	return nil
+}
+
+type GetNVMeNamespaceVisibilityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NamespaceId *_go.ObjectKey `protobuf:"bytes,1,opt,name=namespace_id,json=namespaceId,proto3" json:"namespace_id,omitempty"`
+}
+
+func (x *GetNVMeNamespaceVisibilityRequest) Reset() {
+	*x = GetNVMeNamespaceVisibilityRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvme_namespace_visibility_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNVMeNamespaceVisibilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNVMeNamespaceVisibilityRequest) ProtoMessage() {}
+
+func (x *GetNVMeNamespaceVisibilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvme_namespace_visibility_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNVMeNamespaceVisibilityRequest.ProtoReflect.Descriptor instead.
+func (*GetNVMeNamespaceVisibilityRequest) Descriptor() ([]byte, []int) {
+	return file_nvme_namespace_visibility_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetNVMeNamespaceVisibilityRequest) GetNamespaceId() *_go.ObjectKey {
+	if x != nil {
+		return x.NamespaceId
+	}
+	return nil
+}
+
+var File_nvme_namespace_visibility_proto protoreflect.FileDescriptor
+
+var file_nvme_namespace_visibility_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x5f, 0x76, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x12, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd7, 0x01, 0x0a, 0x17, 0x4e, 0x56, 0x4d, 0x65,
+	0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x0c, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61,
+	0x63, 0x65, 0x49, 0x64, 0x12, 0x43, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f,
+	0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x49, 0x64, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73,
+	0x74, 0x5f, 0x6e, 0x71, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f,
+	0x73, 0x74, 0x4e, 0x71, 0x6e, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6e, 0x79, 0x5f, 0x68, 0x6f,
+	0x73, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6e, 0x79, 0x48, 0x6f, 0x73,
+	0x74, 0x22, 0x73, 0x0a, 0x24, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x0a, 0x76, 0x69, 0x73,
+	0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2b, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65,
+	0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x76, 0x69, 0x73, 0x69,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x64, 0x0a, 0x21, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52,
+	0x0b, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x49, 0x64, 0x32, 0xb0, 0x02, 0x0a,
+	0x1e, 0x4e, 0x56, 0x4d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56, 0x69,
+	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x88, 0x01, 0x0a, 0x1d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x12, 0x38, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2b, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x56, 0x4d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56, 0x69,
+	0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x00, 0x12, 0x82, 0x01, 0x0a, 0x1a, 0x47,
+	0x65, 0x74, 0x4e, 0x56, 0x4d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56,
+	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x35, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x4e, 0x56, 0x4d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x56,
+	0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x2b, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x56, 0x69, 0x73, 0x69, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x00, 0x42,
+	0x1d, 0x5a, 0x1b, 0x6f, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nvme_namespace_visibility_proto_rawDescOnce sync.Once
+	file_nvme_namespace_visibility_proto_rawDescData = file_nvme_namespace_visibility_proto_rawDesc
+)
+
+func file_nvme_namespace_visibility_proto_rawDescGZIP() []byte {
+	file_nvme_namespace_visibility_proto_rawDescOnce.Do(func() {
+		file_nvme_namespace_visibility_proto_rawDescData = protoimpl.X.CompressGZIP(file_nvme_namespace_visibility_proto_rawDescData)
+	})
+	return file_nvme_namespace_visibility_proto_rawDescData
+}
+
+var file_nvme_namespace_visibility_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_nvme_namespace_visibility_proto_goTypes = []interface{}{
+	(*NVMeNamespaceVisibility)(nil),              // 0: opi_spdk_bridge.v1.NVMeNamespaceVisibility
+	(*UpdateNVMeNamespaceVisibilityRequest)(nil), // 1: opi_spdk_bridge.v1.UpdateNVMeNamespaceVisibilityRequest
+	(*GetNVMeNamespaceVisibilityRequest)(nil),    // 2: opi_spdk_bridge.v1.GetNVMeNamespaceVisibilityRequest
+	(*_go.ObjectKey)(nil),                        // 3: opi_api.common.v1.ObjectKey
+}
+var file_nvme_namespace_visibility_proto_depIdxs = []int32{
+	3, // 0: opi_spdk_bridge.v1.NVMeNamespaceVisibility.namespace_id:type_name -> opi_api.common.v1.ObjectKey
+	3, // 1: opi_spdk_bridge.v1.NVMeNamespaceVisibility.controller_ids:type_name -> opi_api.common.v1.ObjectKey
+	0, // 2: opi_spdk_bridge.v1.UpdateNVMeNamespaceVisibilityRequest.visibility:type_name -> opi_spdk_bridge.v1.NVMeNamespaceVisibility
+	3, // 3: opi_spdk_bridge.v1.GetNVMeNamespaceVisibilityRequest.namespace_id:type_name -> opi_api.common.v1.ObjectKey
+	1, // 4: opi_spdk_bridge.v1.NVMeNamespaceVisibilityService.UpdateNVMeNamespaceVisibility:input_type -> opi_spdk_bridge.v1.UpdateNVMeNamespaceVisibilityRequest
+	2, // 5: opi_spdk_bridge.v1.NVMeNamespaceVisibilityService.GetNVMeNamespaceVisibility:input_type -> opi_spdk_bridge.v1.GetNVMeNamespaceVisibilityRequest
+	0, // 6: opi_spdk_bridge.v1.NVMeNamespaceVisibilityService.UpdateNVMeNamespaceVisibility:output_type -> opi_spdk_bridge.v1.NVMeNamespaceVisibility
+	0, // 7: opi_spdk_bridge.v1.NVMeNamespaceVisibilityService.GetNVMeNamespaceVisibility:output_type -> opi_spdk_bridge.v1.NVMeNamespaceVisibility
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_nvme_namespace_visibility_proto_init() }
+func file_nvme_namespace_visibility_proto_init() {
+	if File_nvme_namespace_visibility_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nvme_namespace_visibility_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMeNamespaceVisibility); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvme_namespace_visibility_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateNVMeNamespaceVisibilityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvme_namespace_visibility_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNVMeNamespaceVisibilityRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nvme_namespace_visibility_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nvme_namespace_visibility_proto_goTypes,
+		DependencyIndexes: file_nvme_namespace_visibility_proto_depIdxs,
+		MessageInfos:      file_nvme_namespace_visibility_proto_msgTypes,
+	}.Build()
+	File_nvme_namespace_visibility_proto = out.File
+	file_nvme_namespace_visibility_proto_rawDesc = nil
+	file_nvme_namespace_visibility_proto_goTypes = nil
+	file_nvme_namespace_visibility_proto_depIdxs = nil
+}
@@ -0,0 +1,142 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: nvme_namespace_visibility.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NVMeNamespaceVisibilityServiceClient is the client API for NVMeNamespaceVisibilityService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NVMeNamespaceVisibilityServiceClient interface {
+	UpdateNVMeNamespaceVisibility(ctx context.Context, in *UpdateNVMeNamespaceVisibilityRequest, opts ...grpc.CallOption) (*NVMeNamespaceVisibility, error)
+	GetNVMeNamespaceVisibility(ctx context.Context, in *GetNVMeNamespaceVisibilityRequest, opts ...grpc.CallOption) (*NVMeNamespaceVisibility, error)
+}
+
+type nVMeNamespaceVisibilityServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNVMeNamespaceVisibilityServiceClient(cc grpc.ClientConnInterface) NVMeNamespaceVisibilityServiceClient {
+	return &nVMeNamespaceVisibilityServiceClient{cc}
+}
+
+func (c *nVMeNamespaceVisibilityServiceClient) UpdateNVMeNamespaceVisibility(ctx context.Context, in *UpdateNVMeNamespaceVisibilityRequest, opts ...grpc.CallOption) (*NVMeNamespaceVisibility, error) {
+	out := new(NVMeNamespaceVisibility)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMeNamespaceVisibilityService/UpdateNVMeNamespaceVisibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMeNamespaceVisibilityServiceClient) GetNVMeNamespaceVisibility(ctx context.Context, in *GetNVMeNamespaceVisibilityRequest, opts ...grpc.CallOption) (*NVMeNamespaceVisibility, error) {
+	out := new(NVMeNamespaceVisibility)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMeNamespaceVisibilityService/GetNVMeNamespaceVisibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NVMeNamespaceVisibilityServiceServer is the server API for NVMeNamespaceVisibilityService service.
+// All implementations must embed UnimplementedNVMeNamespaceVisibilityServiceServer
+// for forward compatibility
+type NVMeNamespaceVisibilityServiceServer interface {
+	UpdateNVMeNamespaceVisibility(context.Context, *UpdateNVMeNamespaceVisibilityRequest) (*NVMeNamespaceVisibility, error)
+	GetNVMeNamespaceVisibility(context.Context, *GetNVMeNamespaceVisibilityRequest) (*NVMeNamespaceVisibility, error)
+	mustEmbedUnimplementedNVMeNamespaceVisibilityServiceServer()
+}
+
+// UnimplementedNVMeNamespaceVisibilityServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNVMeNamespaceVisibilityServiceServer struct {
+}
+
+func (UnimplementedNVMeNamespaceVisibilityServiceServer) UpdateNVMeNamespaceVisibility(context.Context, *UpdateNVMeNamespaceVisibilityRequest) (*NVMeNamespaceVisibility, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNVMeNamespaceVisibility not implemented")
+}
+func (UnimplementedNVMeNamespaceVisibilityServiceServer) GetNVMeNamespaceVisibility(context.Context, *GetNVMeNamespaceVisibilityRequest) (*NVMeNamespaceVisibility, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNVMeNamespaceVisibility not implemented")
+}
+func (UnimplementedNVMeNamespaceVisibilityServiceServer) mustEmbedUnimplementedNVMeNamespaceVisibilityServiceServer() {
+}
+
+// UnsafeNVMeNamespaceVisibilityServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NVMeNamespaceVisibilityServiceServer will
+// result in compilation errors.
+type UnsafeNVMeNamespaceVisibilityServiceServer interface {
+	mustEmbedUnimplementedNVMeNamespaceVisibilityServiceServer()
+}
+
+func RegisterNVMeNamespaceVisibilityServiceServer(s grpc.ServiceRegistrar, srv NVMeNamespaceVisibilityServiceServer) {
+	s.RegisterService(&NVMeNamespaceVisibilityService_ServiceDesc, srv)
+}
+
+func _NVMeNamespaceVisibilityService_UpdateNVMeNamespaceVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNVMeNamespaceVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMeNamespaceVisibilityServiceServer).UpdateNVMeNamespaceVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMeNamespaceVisibilityService/UpdateNVMeNamespaceVisibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMeNamespaceVisibilityServiceServer).UpdateNVMeNamespaceVisibility(ctx, req.(*UpdateNVMeNamespaceVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMeNamespaceVisibilityService_GetNVMeNamespaceVisibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNVMeNamespaceVisibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMeNamespaceVisibilityServiceServer).GetNVMeNamespaceVisibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMeNamespaceVisibilityService/GetNVMeNamespaceVisibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMeNamespaceVisibilityServiceServer).GetNVMeNamespaceVisibility(ctx, req.(*GetNVMeNamespaceVisibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NVMeNamespaceVisibilityService_ServiceDesc is the grpc.ServiceDesc for NVMeNamespaceVisibilityService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NVMeNamespaceVisibilityService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.v1.NVMeNamespaceVisibilityService",
+	HandlerType: (*NVMeNamespaceVisibilityServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpdateNVMeNamespaceVisibility",
+			Handler:    _NVMeNamespaceVisibilityService_UpdateNVMeNamespaceVisibility_Handler,
+		},
+		{
+			MethodName: "GetNVMeNamespaceVisibility",
+			Handler:    _NVMeNamespaceVisibilityService_GetNVMeNamespaceVisibility_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nvme_namespace_visibility.proto",
+}
@@ -0,0 +1,780 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: nvmf_host.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/common/v1/gen/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A host allowed to connect to a subsystem
+type NVMfHost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spec *NVMfHostSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+}
+
+func (x *NVMfHost) Reset() {
+	*x = NVMfHost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfHost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfHost) ProtoMessage() {}
+
+func (x *NVMfHost) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfHost.ProtoReflect.Descriptor instead.
+func (*NVMfHost) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NVMfHost) GetSpec() *NVMfHostSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+type NVMfHostSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// object's unique identifier
+	Id *_go.ObjectKey `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// subsystem the host may connect to
+	SubsystemId *_go.ObjectKey `protobuf:"bytes,2,opt,name=subsystem_id,json=subsystemId,proto3" json:"subsystem_id,omitempty"`
+	// NQN of the host
+	HostNqn string `protobuf:"bytes,3,opt,name=host_nqn,json=hostNqn,proto3" json:"host_nqn,omitempty"`
+}
+
+func (x *NVMfHostSpec) Reset() {
+	*x = NVMfHostSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfHostSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfHostSpec) ProtoMessage() {}
+
+func (x *NVMfHostSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfHostSpec.ProtoReflect.Descriptor instead.
+func (*NVMfHostSpec) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NVMfHostSpec) GetId() *_go.ObjectKey {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *NVMfHostSpec) GetSubsystemId() *_go.ObjectKey {
+	if x != nil {
+		return x.SubsystemId
+	}
+	return nil
+}
+
+func (x *NVMfHostSpec) GetHostNqn() string {
+	if x != nil {
+		return x.HostNqn
+	}
+	return ""
+}
+
+// Access control of a subsystem
+type NVMfSubsystemAccess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubsystemId *_go.ObjectKey `protobuf:"bytes,1,opt,name=subsystem_id,json=subsystemId,proto3" json:"subsystem_id,omitempty"`
+	// any host may connect, not only the allowed ones
+	AllowAnyHost bool `protobuf:"varint,2,opt,name=allow_any_host,json=allowAnyHost,proto3" json:"allow_any_host,omitempty"`
+	// NQNs of the allowed hosts, output only
+	HostNqns []string `protobuf:"bytes,3,rep,name=host_nqns,json=hostNqns,proto3" json:"host_nqns,omitempty"`
+}
+
+func (x *NVMfSubsystemAccess) Reset() {
+	*x = NVMfSubsystemAccess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfSubsystemAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfSubsystemAccess) ProtoMessage() {}
+
+func (x *NVMfSubsystemAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfSubsystemAccess.ProtoReflect.Descriptor instead.
+func (*NVMfSubsystemAccess) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NVMfSubsystemAccess) GetSubsystemId() *_go.ObjectKey {
+	if x != nil {
+		return x.SubsystemId
+	}
+	return nil
+}
+
+func (x *NVMfSubsystemAccess) GetAllowAnyHost() bool {
+	if x != nil {
+		return x.AllowAnyHost
+	}
+	return false
+}
+
+func (x *NVMfSubsystemAccess) GetHostNqns() []string {
+	if x != nil {
+		return x.HostNqns
+	}
+	return nil
+}
+
+type CreateNVMfHostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Host *NVMfHost `protobuf:"bytes,1,opt,name=host,proto3" json:"host,omitempty"`
+}
+
+func (x *CreateNVMfHostRequest) Reset() {
+	*x = CreateNVMfHostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateNVMfHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNVMfHostRequest) ProtoMessage() {}
+
+func (x *CreateNVMfHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNVMfHostRequest.ProtoReflect.Descriptor instead.
+func (*CreateNVMfHostRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateNVMfHostRequest) GetHost() *NVMfHost {
+	if x != nil {
+		return x.Host
+	}
+	return nil
+}
+
+type DeleteNVMfHostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HostId *_go.ObjectKey `protobuf:"bytes,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+}
+
+func (x *DeleteNVMfHostRequest) Reset() {
+	*x = DeleteNVMfHostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteNVMfHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNVMfHostRequest) ProtoMessage() {}
+
+func (x *DeleteNVMfHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNVMfHostRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNVMfHostRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteNVMfHostRequest) GetHostId() *_go.ObjectKey {
+	if x != nil {
+		return x.HostId
+	}
+	return nil
+}
+
+type ListNVMfHostRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubsystemId *_go.ObjectKey `protobuf:"bytes,1,opt,name=subsystem_id,json=subsystemId,proto3" json:"subsystem_id,omitempty"`
+}
+
+func (x *ListNVMfHostRequest) Reset() {
+	*x = ListNVMfHostRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNVMfHostRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNVMfHostRequest) ProtoMessage() {}
+
+func (x *ListNVMfHostRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNVMfHostRequest.ProtoReflect.Descriptor instead.
+func (*ListNVMfHostRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListNVMfHostRequest) GetSubsystemId() *_go.ObjectKey {
+	if x != nil {
+		return x.SubsystemId
+	}
+	return nil
+}
+
+type ListNVMfHostResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hosts []*NVMfHost `protobuf:"bytes,1,rep,name=hosts,proto3" json:"hosts,omitempty"`
+}
+
+func (x *ListNVMfHostResponse) Reset() {
+	*x = ListNVMfHostResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNVMfHostResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNVMfHostResponse) ProtoMessage() {}
+
+func (x *ListNVMfHostResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNVMfHostResponse.ProtoReflect.Descriptor instead.
+func (*ListNVMfHostResponse) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListNVMfHostResponse) GetHosts() []*NVMfHost {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type UpdateNVMfSubsystemAccessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Access *NVMfSubsystemAccess `protobuf:"bytes,1,opt,name=access,proto3" json:"access,omitempty"`
+}
+
+func (x *UpdateNVMfSubsystemAccessRequest) Reset() {
+	*x = UpdateNVMfSubsystemAccessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateNVMfSubsystemAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateNVMfSubsystemAccessRequest) ProtoMessage() {}
+
+func (x *UpdateNVMfSubsystemAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateNVMfSubsystemAccessRequest.ProtoReflect.Descriptor instead.
+func (*UpdateNVMfSubsystemAccessRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateNVMfSubsystemAccessRequest) GetAccess() *NVMfSubsystemAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+type GetNVMfSubsystemAccessRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubsystemId *_go.ObjectKey `protobuf:"bytes,1,opt,name=subsystem_id,json=subsystemId,proto3" json:"subsystem_id,omitempty"`
+}
+
+func (x *GetNVMfSubsystemAccessRequest) Reset() {
+	*x = GetNVMfSubsystemAccessRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_host_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNVMfSubsystemAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNVMfSubsystemAccessRequest) ProtoMessage() {}
+
+func (x *GetNVMfSubsystemAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_host_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNVMfSubsystemAccessRequest.ProtoReflect.Descriptor instead.
+func (*GetNVMfSubsystemAccessRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_host_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetNVMfSubsystemAccessRequest) GetSubsystemId() *_go.ObjectKey {
+	if x != nil {
+		return x.SubsystemId
+	}
+	return nil
+}
+
+var File_nvmf_host_proto protoreflect.FileDescriptor
+
+var file_nvmf_host_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x6e, 0x76, 0x6d, 0x66, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x12, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65,
+	0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x40, 0x0a, 0x08, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74,
+	0x12, 0x34, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x53, 0x70, 0x65, 0x63,
+	0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x98, 0x01, 0x0a, 0x0c, 0x4e, 0x56, 0x4d, 0x66, 0x48,
+	0x6f, 0x73, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2c, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f,
+	0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65,
+	0x79, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3f, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6e,
+	0x71, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x71,
+	0x6e, 0x22, 0x99, 0x01, 0x0a, 0x13, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x3f, 0x0a, 0x0c, 0x73, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0b, 0x73,
+	0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x5f, 0x61, 0x6e, 0x79, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x41, 0x6e, 0x79, 0x48, 0x6f, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x71, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x71, 0x6e, 0x73, 0x22, 0x49, 0x0a,
+	0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f,
+	0x73, 0x74, 0x52, 0x04, 0x68, 0x6f, 0x73, 0x74, 0x22, 0x4e, 0x0a, 0x15, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x35, 0x0a, 0x07, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79,
+	0x52, 0x06, 0x68, 0x6f, 0x73, 0x74, 0x49, 0x64, 0x22, 0x56, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74,
+	0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x3f, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x4b, 0x65, 0x79, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64,
+	0x22, 0x4a, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x68, 0x6f, 0x73, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70,
+	0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d,
+	0x66, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x05, 0x68, 0x6f, 0x73, 0x74, 0x73, 0x22, 0x63, 0x0a, 0x20,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3f, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x22, 0x60, 0x0a, 0x1d, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x49, 0x64, 0x32, 0xa0, 0x04, 0x0a, 0x0f, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x0e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x29, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f,
+	0x73, 0x74, 0x22, 0x00, 0x12, 0x55, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4e, 0x56,
+	0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x29, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x63, 0x0a, 0x0c, 0x4c,
+	0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x12, 0x27, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x28, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56,
+	0x4d, 0x66, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x7c, 0x0a, 0x19, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75,
+	0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x34, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x12, 0x76,
+	0x0a, 0x16, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x31, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73,
+	0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x41, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x22, 0x00, 0x42, 0x1d, 0x5a, 0x1b, 0x6f, 0x70, 0x69, 0x2e, 0x73, 0x74,
+	0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
+	0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nvmf_host_proto_rawDescOnce sync.Once
+	file_nvmf_host_proto_rawDescData = file_nvmf_host_proto_rawDesc
+)
+
+func file_nvmf_host_proto_rawDescGZIP() []byte {
+	file_nvmf_host_proto_rawDescOnce.Do(func() {
+		file_nvmf_host_proto_rawDescData = protoimpl.X.CompressGZIP(file_nvmf_host_proto_rawDescData)
+	})
+	return file_nvmf_host_proto_rawDescData
+}
+
+var file_nvmf_host_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_nvmf_host_proto_goTypes = []interface{}{
+	(*NVMfHost)(nil),                         // 0: opi_spdk_bridge.v1.NVMfHost
+	(*NVMfHostSpec)(nil),                     // 1: opi_spdk_bridge.v1.NVMfHostSpec
+	(*NVMfSubsystemAccess)(nil),              // 2: opi_spdk_bridge.v1.NVMfSubsystemAccess
+	(*CreateNVMfHostRequest)(nil),            // 3: opi_spdk_bridge.v1.CreateNVMfHostRequest
+	(*DeleteNVMfHostRequest)(nil),            // 4: opi_spdk_bridge.v1.DeleteNVMfHostRequest
+	(*ListNVMfHostRequest)(nil),              // 5: opi_spdk_bridge.v1.ListNVMfHostRequest
+	(*ListNVMfHostResponse)(nil),             // 6: opi_spdk_bridge.v1.ListNVMfHostResponse
+	(*UpdateNVMfSubsystemAccessRequest)(nil), // 7: opi_spdk_bridge.v1.UpdateNVMfSubsystemAccessRequest
+	(*GetNVMfSubsystemAccessRequest)(nil),    // 8: opi_spdk_bridge.v1.GetNVMfSubsystemAccessRequest
+	(*_go.ObjectKey)(nil),                    // 9: opi_api.common.v1.ObjectKey
+	(*emptypb.Empty)(nil),                    // 10: google.protobuf.Empty
+}
+var file_nvmf_host_proto_depIdxs = []int32{
+	1,  // 0: opi_spdk_bridge.v1.NVMfHost.spec:type_name -> opi_spdk_bridge.v1.NVMfHostSpec
+	9,  // 1: opi_spdk_bridge.v1.NVMfHostSpec.id:type_name -> opi_api.common.v1.ObjectKey
+	9,  // 2: opi_spdk_bridge.v1.NVMfHostSpec.subsystem_id:type_name -> opi_api.common.v1.ObjectKey
+	9,  // 3: opi_spdk_bridge.v1.NVMfSubsystemAccess.subsystem_id:type_name -> opi_api.common.v1.ObjectKey
+	0,  // 4: opi_spdk_bridge.v1.CreateNVMfHostRequest.host:type_name -> opi_spdk_bridge.v1.NVMfHost
+	9,  // 5: opi_spdk_bridge.v1.DeleteNVMfHostRequest.host_id:type_name -> opi_api.common.v1.ObjectKey
+	9,  // 6: opi_spdk_bridge.v1.ListNVMfHostRequest.subsystem_id:type_name -> opi_api.common.v1.ObjectKey
+	0,  // 7: opi_spdk_bridge.v1.ListNVMfHostResponse.hosts:type_name -> opi_spdk_bridge.v1.NVMfHost
+	2,  // 8: opi_spdk_bridge.v1.UpdateNVMfSubsystemAccessRequest.access:type_name -> opi_spdk_bridge.v1.NVMfSubsystemAccess
+	9,  // 9: opi_spdk_bridge.v1.GetNVMfSubsystemAccessRequest.subsystem_id:type_name -> opi_api.common.v1.ObjectKey
+	3,  // 10: opi_spdk_bridge.v1.NVMfHostService.CreateNVMfHost:input_type -> opi_spdk_bridge.v1.CreateNVMfHostRequest
+	4,  // 11: opi_spdk_bridge.v1.NVMfHostService.DeleteNVMfHost:input_type -> opi_spdk_bridge.v1.DeleteNVMfHostRequest
+	5,  // 12: opi_spdk_bridge.v1.NVMfHostService.ListNVMfHost:input_type -> opi_spdk_bridge.v1.ListNVMfHostRequest
+	7,  // 13: opi_spdk_bridge.v1.NVMfHostService.UpdateNVMfSubsystemAccess:input_type -> opi_spdk_bridge.v1.UpdateNVMfSubsystemAccessRequest
+	8,  // 14: opi_spdk_bridge.v1.NVMfHostService.GetNVMfSubsystemAccess:input_type -> opi_spdk_bridge.v1.GetNVMfSubsystemAccessRequest
+	0,  // 15: opi_spdk_bridge.v1.NVMfHostService.CreateNVMfHost:output_type -> opi_spdk_bridge.v1.NVMfHost
+	10, // 16: opi_spdk_bridge.v1.NVMfHostService.DeleteNVMfHost:output_type -> google.protobuf.Empty
+	6,  // 17: opi_spdk_bridge.v1.NVMfHostService.ListNVMfHost:output_type -> opi_spdk_bridge.v1.ListNVMfHostResponse
+	2,  // 18: opi_spdk_bridge.v1.NVMfHostService.UpdateNVMfSubsystemAccess:output_type -> opi_spdk_bridge.v1.NVMfSubsystemAccess
+	2,  // 19: opi_spdk_bridge.v1.NVMfHostService.GetNVMfSubsystemAccess:output_type -> opi_spdk_bridge.v1.NVMfSubsystemAccess
+	15, // [15:20] is the sub-list for method output_type
+	10, // [10:15] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_nvmf_host_proto_init() }
+func file_nvmf_host_proto_init() {
+	if File_nvmf_host_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nvmf_host_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfHost); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfHostSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfSubsystemAccess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateNVMfHostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteNVMfHostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNVMfHostRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNVMfHostResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateNVMfSubsystemAccessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_host_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNVMfSubsystemAccessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nvmf_host_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nvmf_host_proto_goTypes,
+		DependencyIndexes: file_nvmf_host_proto_depIdxs,
+		MessageInfos:      file_nvmf_host_proto_msgTypes,
+	}.Build()
+	File_nvmf_host_proto = out.File
+	file_nvmf_host_proto_rawDesc = nil
+	file_nvmf_host_proto_goTypes = nil
+	file_nvmf_host_proto_depIdxs = nil
+}
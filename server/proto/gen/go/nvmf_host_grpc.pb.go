@@ -0,0 +1,250 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: nvmf_host.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NVMfHostServiceClient is the client API for NVMfHostService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NVMfHostServiceClient interface {
+	CreateNVMfHost(ctx context.Context, in *CreateNVMfHostRequest, opts ...grpc.CallOption) (*NVMfHost, error)
+	DeleteNVMfHost(ctx context.Context, in *DeleteNVMfHostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListNVMfHost(ctx context.Context, in *ListNVMfHostRequest, opts ...grpc.CallOption) (*ListNVMfHostResponse, error)
+	UpdateNVMfSubsystemAccess(ctx context.Context, in *UpdateNVMfSubsystemAccessRequest, opts ...grpc.CallOption) (*NVMfSubsystemAccess, error)
+	GetNVMfSubsystemAccess(ctx context.Context, in *GetNVMfSubsystemAccessRequest, opts ...grpc.CallOption) (*NVMfSubsystemAccess, error)
+}
+
+type nVMfHostServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNVMfHostServiceClient(cc grpc.ClientConnInterface) NVMfHostServiceClient {
+	return &nVMfHostServiceClient{cc}
+}
+
+func (c *nVMfHostServiceClient) CreateNVMfHost(ctx context.Context, in *CreateNVMfHostRequest, opts ...grpc.CallOption) (*NVMfHost, error) {
+	out := new(NVMfHost)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfHostService/CreateNVMfHost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfHostServiceClient) DeleteNVMfHost(ctx context.Context, in *DeleteNVMfHostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfHostService/DeleteNVMfHost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfHostServiceClient) ListNVMfHost(ctx context.Context, in *ListNVMfHostRequest, opts ...grpc.CallOption) (*ListNVMfHostResponse, error) {
+	out := new(ListNVMfHostResponse)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfHostService/ListNVMfHost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfHostServiceClient) UpdateNVMfSubsystemAccess(ctx context.Context, in *UpdateNVMfSubsystemAccessRequest, opts ...grpc.CallOption) (*NVMfSubsystemAccess, error) {
+	out := new(NVMfSubsystemAccess)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfHostService/UpdateNVMfSubsystemAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfHostServiceClient) GetNVMfSubsystemAccess(ctx context.Context, in *GetNVMfSubsystemAccessRequest, opts ...grpc.CallOption) (*NVMfSubsystemAccess, error) {
+	out := new(NVMfSubsystemAccess)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfHostService/GetNVMfSubsystemAccess", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NVMfHostServiceServer is the server API for NVMfHostService service.
+// All implementations must embed UnimplementedNVMfHostServiceServer
+// for forward compatibility
+type NVMfHostServiceServer interface {
+	CreateNVMfHost(context.Context, *CreateNVMfHostRequest) (*NVMfHost, error)
+	DeleteNVMfHost(context.Context, *DeleteNVMfHostRequest) (*emptypb.Empty, error)
+	ListNVMfHost(context.Context, *ListNVMfHostRequest) (*ListNVMfHostResponse, error)
+	UpdateNVMfSubsystemAccess(context.Context, *UpdateNVMfSubsystemAccessRequest) (*NVMfSubsystemAccess, error)
+	GetNVMfSubsystemAccess(context.Context, *GetNVMfSubsystemAccessRequest) (*NVMfSubsystemAccess, error)
+	mustEmbedUnimplementedNVMfHostServiceServer()
+}
+
+// UnimplementedNVMfHostServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNVMfHostServiceServer struct {
+}
+
+func (UnimplementedNVMfHostServiceServer) CreateNVMfHost(context.Context, *CreateNVMfHostRequest) (*NVMfHost, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNVMfHost not implemented")
+}
+func (UnimplementedNVMfHostServiceServer) DeleteNVMfHost(context.Context, *DeleteNVMfHostRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNVMfHost not implemented")
+}
+func (UnimplementedNVMfHostServiceServer) ListNVMfHost(context.Context, *ListNVMfHostRequest) (*ListNVMfHostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNVMfHost not implemented")
+}
+func (UnimplementedNVMfHostServiceServer) UpdateNVMfSubsystemAccess(context.Context, *UpdateNVMfSubsystemAccessRequest) (*NVMfSubsystemAccess, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateNVMfSubsystemAccess not implemented")
+}
+func (UnimplementedNVMfHostServiceServer) GetNVMfSubsystemAccess(context.Context, *GetNVMfSubsystemAccessRequest) (*NVMfSubsystemAccess, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNVMfSubsystemAccess not implemented")
+}
+func (UnimplementedNVMfHostServiceServer) mustEmbedUnimplementedNVMfHostServiceServer() {}
+
+// UnsafeNVMfHostServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NVMfHostServiceServer will
+// result in compilation errors.
+type UnsafeNVMfHostServiceServer interface {
+	mustEmbedUnimplementedNVMfHostServiceServer()
+}
+
+func RegisterNVMfHostServiceServer(s grpc.ServiceRegistrar, srv NVMfHostServiceServer) {
+	s.RegisterService(&NVMfHostService_ServiceDesc, srv)
+}
+
+func _NVMfHostService_CreateNVMfHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNVMfHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfHostServiceServer).CreateNVMfHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfHostService/CreateNVMfHost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfHostServiceServer).CreateNVMfHost(ctx, req.(*CreateNVMfHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfHostService_DeleteNVMfHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNVMfHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfHostServiceServer).DeleteNVMfHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfHostService/DeleteNVMfHost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfHostServiceServer).DeleteNVMfHost(ctx, req.(*DeleteNVMfHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfHostService_ListNVMfHost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNVMfHostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfHostServiceServer).ListNVMfHost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfHostService/ListNVMfHost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfHostServiceServer).ListNVMfHost(ctx, req.(*ListNVMfHostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfHostService_UpdateNVMfSubsystemAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNVMfSubsystemAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfHostServiceServer).UpdateNVMfSubsystemAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfHostService/UpdateNVMfSubsystemAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfHostServiceServer).UpdateNVMfSubsystemAccess(ctx, req.(*UpdateNVMfSubsystemAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfHostService_GetNVMfSubsystemAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNVMfSubsystemAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfHostServiceServer).GetNVMfSubsystemAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfHostService/GetNVMfSubsystemAccess",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfHostServiceServer).GetNVMfSubsystemAccess(ctx, req.(*GetNVMfSubsystemAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NVMfHostService_ServiceDesc is the grpc.ServiceDesc for NVMfHostService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NVMfHostService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.v1.NVMfHostService",
+	HandlerType: (*NVMfHostServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateNVMfHost",
+			Handler:    _NVMfHostService_CreateNVMfHost_Handler,
+		},
+		{
+			MethodName: "DeleteNVMfHost",
+			Handler:    _NVMfHostService_DeleteNVMfHost_Handler,
+		},
+		{
+			MethodName: "ListNVMfHost",
+			Handler:    _NVMfHostService_ListNVMfHost_Handler,
+		},
+		{
+			MethodName: "UpdateNVMfSubsystemAccess",
+			Handler:    _NVMfHostService_UpdateNVMfSubsystemAccess_Handler,
+		},
+		{
+			MethodName: "GetNVMfSubsystemAccess",
+			Handler:    _NVMfHostService_GetNVMfSubsystemAccess_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nvmf_host.proto",
+}
@@ -0,0 +1,818 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: nvmf_listener.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/common/v1/gen/go"
+	_go1 "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NVMfAnaState int32
+
+const (
+	NVMfAnaState_NVMF_ANA_STATE_UNSPECIFIED   NVMfAnaState = 0
+	NVMfAnaState_NVMF_ANA_STATE_OPTIMIZED     NVMfAnaState = 1
+	NVMfAnaState_NVMF_ANA_STATE_NON_OPTIMIZED NVMfAnaState = 2
+	NVMfAnaState_NVMF_ANA_STATE_INACCESSIBLE  NVMfAnaState = 3
+)
+
+// Enum value maps for NVMfAnaState.
+var (
+	NVMfAnaState_name = map[int32]string{
+		0: "NVMF_ANA_STATE_UNSPECIFIED",
+		1: "NVMF_ANA_STATE_OPTIMIZED",
+		2: "NVMF_ANA_STATE_NON_OPTIMIZED",
+		3: "NVMF_ANA_STATE_INACCESSIBLE",
+	}
+	NVMfAnaState_value = map[string]int32{
+		"NVMF_ANA_STATE_UNSPECIFIED":   0,
+		"NVMF_ANA_STATE_OPTIMIZED":     1,
+		"NVMF_ANA_STATE_NON_OPTIMIZED": 2,
+		"NVMF_ANA_STATE_INACCESSIBLE":  3,
+	}
+)
+
+func (x NVMfAnaState) Enum() *NVMfAnaState {
+	p := new(NVMfAnaState)
+	*p = x
+	return p
+}
+
+func (x NVMfAnaState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NVMfAnaState) Descriptor() protoreflect.EnumDescriptor {
+	return file_nvmf_listener_proto_enumTypes[0].Descriptor()
+}
+
+func (NVMfAnaState) Type() protoreflect.EnumType {
+	return &file_nvmf_listener_proto_enumTypes[0]
+}
+
+func (x NVMfAnaState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NVMfAnaState.Descriptor instead.
+func (NVMfAnaState) EnumDescriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{0}
+}
+
+type NVMfListener struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spec   *NVMfListenerSpec   `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Status *NVMfListenerStatus `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *NVMfListener) Reset() {
+	*x = NVMfListener{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfListener) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfListener) ProtoMessage() {}
+
+func (x *NVMfListener) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfListener.ProtoReflect.Descriptor instead.
+func (*NVMfListener) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NVMfListener) GetSpec() *NVMfListenerSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *NVMfListener) GetStatus() *NVMfListenerStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type NVMfListenerSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// object's unique identifier
+	Id *_go.ObjectKey `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// subsystem listening on the address
+	SubsystemId *_go.ObjectKey `protobuf:"bytes,2,opt,name=subsystem_id,json=subsystemId,proto3" json:"subsystem_id,omitempty"`
+	// transport type, NVME_TRANSPORT_PCIE for vfio-user
+	Trtype _go1.NvmeTransportType `protobuf:"varint,3,opt,name=trtype,proto3,enum=opi_api.storage.v1.NvmeTransportType" json:"trtype,omitempty"`
+	// address family, not used by vfio-user
+	Adrfam _go1.NvmeAddressFamily `protobuf:"varint,4,opt,name=adrfam,proto3,enum=opi_api.storage.v1.NvmeAddressFamily" json:"adrfam,omitempty"`
+	// transport address, the socket directory for vfio-user
+	Traddr string `protobuf:"bytes,5,opt,name=traddr,proto3" json:"traddr,omitempty"`
+	// transport service ID, the port for TCP and RDMA
+	Trsvcid string `protobuf:"bytes,6,opt,name=trsvcid,proto3" json:"trsvcid,omitempty"`
+	// ANA state of the listener, optimized if not given
+	AnaState NVMfAnaState `protobuf:"varint,7,opt,name=ana_state,json=anaState,proto3,enum=opi_spdk_bridge.v1.NVMfAnaState" json:"ana_state,omitempty"`
+}
+
+func (x *NVMfListenerSpec) Reset() {
+	*x = NVMfListenerSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfListenerSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfListenerSpec) ProtoMessage() {}
+
+func (x *NVMfListenerSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfListenerSpec.ProtoReflect.Descriptor instead.
+func (*NVMfListenerSpec) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NVMfListenerSpec) GetId() *_go.ObjectKey {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *NVMfListenerSpec) GetSubsystemId() *_go.ObjectKey {
+	if x != nil {
+		return x.SubsystemId
+	}
+	return nil
+}
+
+func (x *NVMfListenerSpec) GetTrtype() _go1.NvmeTransportType {
+	if x != nil {
+		return x.Trtype
+	}
+	return _go1.NvmeTransportType(0)
+}
+
+func (x *NVMfListenerSpec) GetAdrfam() _go1.NvmeAddressFamily {
+	if x != nil {
+		return x.Adrfam
+	}
+	return _go1.NvmeAddressFamily(0)
+}
+
+func (x *NVMfListenerSpec) GetTraddr() string {
+	if x != nil {
+		return x.Traddr
+	}
+	return ""
+}
+
+func (x *NVMfListenerSpec) GetTrsvcid() string {
+	if x != nil {
+		return x.Trsvcid
+	}
+	return ""
+}
+
+func (x *NVMfListenerSpec) GetAnaState() NVMfAnaState {
+	if x != nil {
+		return x.AnaState
+	}
+	return NVMfAnaState_NVMF_ANA_STATE_UNSPECIFIED
+}
+
+type NVMfListenerStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ANA state SPDK reports for the first ANA group
+	AnaState NVMfAnaState `protobuf:"varint,1,opt,name=ana_state,json=anaState,proto3,enum=opi_spdk_bridge.v1.NVMfAnaState" json:"ana_state,omitempty"`
+}
+
+func (x *NVMfListenerStatus) Reset() {
+	*x = NVMfListenerStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfListenerStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfListenerStatus) ProtoMessage() {}
+
+func (x *NVMfListenerStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfListenerStatus.ProtoReflect.Descriptor instead.
+func (*NVMfListenerStatus) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *NVMfListenerStatus) GetAnaState() NVMfAnaState {
+	if x != nil {
+		return x.AnaState
+	}
+	return NVMfAnaState_NVMF_ANA_STATE_UNSPECIFIED
+}
+
+type CreateNVMfListenerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Listener *NVMfListener `protobuf:"bytes,1,opt,name=listener,proto3" json:"listener,omitempty"`
+}
+
+func (x *CreateNVMfListenerRequest) Reset() {
+	*x = CreateNVMfListenerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateNVMfListenerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNVMfListenerRequest) ProtoMessage() {}
+
+func (x *CreateNVMfListenerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNVMfListenerRequest.ProtoReflect.Descriptor instead.
+func (*CreateNVMfListenerRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CreateNVMfListenerRequest) GetListener() *NVMfListener {
+	if x != nil {
+		return x.Listener
+	}
+	return nil
+}
+
+type DeleteNVMfListenerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ListenerId *_go.ObjectKey `protobuf:"bytes,1,opt,name=listener_id,json=listenerId,proto3" json:"listener_id,omitempty"`
+}
+
+func (x *DeleteNVMfListenerRequest) Reset() {
+	*x = DeleteNVMfListenerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteNVMfListenerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteNVMfListenerRequest) ProtoMessage() {}
+
+func (x *DeleteNVMfListenerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteNVMfListenerRequest.ProtoReflect.Descriptor instead.
+func (*DeleteNVMfListenerRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeleteNVMfListenerRequest) GetListenerId() *_go.ObjectKey {
+	if x != nil {
+		return x.ListenerId
+	}
+	return nil
+}
+
+type ListNVMfListenerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubsystemId *_go.ObjectKey `protobuf:"bytes,1,opt,name=subsystem_id,json=subsystemId,proto3" json:"subsystem_id,omitempty"`
+}
+
+func (x *ListNVMfListenerRequest) Reset() {
+	*x = ListNVMfListenerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNVMfListenerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNVMfListenerRequest) ProtoMessage() {}
+
+func (x *ListNVMfListenerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNVMfListenerRequest.ProtoReflect.Descriptor instead.
+func (*ListNVMfListenerRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListNVMfListenerRequest) GetSubsystemId() *_go.ObjectKey {
+	if x != nil {
+		return x.SubsystemId
+	}
+	return nil
+}
+
+type ListNVMfListenerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Listeners []*NVMfListener `protobuf:"bytes,1,rep,name=listeners,proto3" json:"listeners,omitempty"`
+}
+
+func (x *ListNVMfListenerResponse) Reset() {
+	*x = ListNVMfListenerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNVMfListenerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNVMfListenerResponse) ProtoMessage() {}
+
+func (x *ListNVMfListenerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNVMfListenerResponse.ProtoReflect.Descriptor instead.
+func (*ListNVMfListenerResponse) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListNVMfListenerResponse) GetListeners() []*NVMfListener {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+type GetNVMfListenerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ListenerId *_go.ObjectKey `protobuf:"bytes,1,opt,name=listener_id,json=listenerId,proto3" json:"listener_id,omitempty"`
+}
+
+func (x *GetNVMfListenerRequest) Reset() {
+	*x = GetNVMfListenerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_listener_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNVMfListenerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNVMfListenerRequest) ProtoMessage() {}
+
+func (x *GetNVMfListenerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_listener_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNVMfListenerRequest.ProtoReflect.Descriptor instead.
+func (*GetNVMfListenerRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_listener_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetNVMfListenerRequest) GetListenerId() *_go.ObjectKey {
+	if x != nil {
+		return x.ListenerId
+	}
+	return nil
+}
+
+var File_nvmf_listener_proto protoreflect.FileDescriptor
+
+var file_nvmf_listener_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x6e, 0x76, 0x6d, 0x66, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x62, 0x61, 0x63,
+	0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x74, 0x63, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x88, 0x01, 0x0a, 0x0c, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x12, 0x38, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x3e, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xf0, 0x02, 0x0a, 0x10,
+	0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x70, 0x65, 0x63,
+	0x12, 0x2c, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f,
+	0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x02, 0x69, 0x64, 0x12, 0x3f,
+	0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b,
+	0x65, 0x79, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x12,
+	0x3d, 0x0a, 0x06, 0x74, 0x72, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x25, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x74, 0x72, 0x74, 0x79, 0x70, 0x65, 0x12, 0x3d,
+	0x0a, 0x06, 0x61, 0x64, 0x72, 0x66, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x46,
+	0x61, 0x6d, 0x69, 0x6c, 0x79, 0x52, 0x06, 0x61, 0x64, 0x72, 0x66, 0x61, 0x6d, 0x12, 0x16, 0x0a,
+	0x06, 0x74, 0x72, 0x61, 0x64, 0x64, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
+	0x72, 0x61, 0x64, 0x64, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72, 0x73, 0x76, 0x63, 0x69, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x73, 0x76, 0x63, 0x69, 0x64, 0x12,
+	0x3d, 0x0a, 0x09, 0x61, 0x6e, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72,
+	0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x41, 0x6e, 0x61, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x08, 0x61, 0x6e, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0x53,
+	0x0a, 0x12, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x3d, 0x0a, 0x09, 0x61, 0x6e, 0x61, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70,
+	0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d,
+	0x66, 0x41, 0x6e, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x08, 0x61, 0x6e, 0x61, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x22, 0x59, 0x0a, 0x19, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d,
+	0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3c, 0x0a, 0x08, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72,
+	0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x52, 0x08, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x22, 0x5a,
+	0x0a, 0x19, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b, 0x6c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0a,
+	0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x22, 0x5a, 0x0a, 0x17, 0x4c, 0x69,
+	0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0c, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x22, 0x5a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56,
+	0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3e, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x73, 0x22, 0x57, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3d, 0x0a, 0x0b,
+	0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d,
+	0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52,
+	0x0a, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x2a, 0x8f, 0x01, 0x0a, 0x0c,
+	0x4e, 0x56, 0x4d, 0x66, 0x41, 0x6e, 0x61, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x1a,
+	0x4e, 0x56, 0x4d, 0x46, 0x5f, 0x41, 0x4e, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55,
+	0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18,
+	0x4e, 0x56, 0x4d, 0x46, 0x5f, 0x41, 0x4e, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4f,
+	0x50, 0x54, 0x49, 0x4d, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x4e, 0x56,
+	0x4d, 0x46, 0x5f, 0x41, 0x4e, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x4e, 0x4f, 0x4e,
+	0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4d, 0x49, 0x5a, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1f, 0x0a, 0x1b,
+	0x4e, 0x56, 0x4d, 0x46, 0x5f, 0x41, 0x4e, 0x41, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x49,
+	0x4e, 0x41, 0x43, 0x43, 0x45, 0x53, 0x53, 0x49, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x32, 0xb1, 0x03,
+	0x0a, 0x13, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x67, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e,
+	0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x12, 0x2d, 0x2e, 0x6f, 0x70,
+	0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x22, 0x00, 0x12, 0x5d,
+	0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x12, 0x2d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x6f, 0x0a,
+	0x10, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x12, 0x2b, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2c,
+	0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74,
+	0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x61,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x12, 0x2a, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x22,
+	0x00, 0x42, 0x1d, 0x5a, 0x1b, 0x6f, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x2e, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nvmf_listener_proto_rawDescOnce sync.Once
+	file_nvmf_listener_proto_rawDescData = file_nvmf_listener_proto_rawDesc
+)
+
+func file_nvmf_listener_proto_rawDescGZIP() []byte {
+	file_nvmf_listener_proto_rawDescOnce.Do(func() {
+		file_nvmf_listener_proto_rawDescData = protoimpl.X.CompressGZIP(file_nvmf_listener_proto_rawDescData)
+	})
+	return file_nvmf_listener_proto_rawDescData
+}
+
+var file_nvmf_listener_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_nvmf_listener_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_nvmf_listener_proto_goTypes = []interface{}{
+	(NVMfAnaState)(0),                 // 0: opi_spdk_bridge.v1.NVMfAnaState
+	(*NVMfListener)(nil),              // 1: opi_spdk_bridge.v1.NVMfListener
+	(*NVMfListenerSpec)(nil),          // 2: opi_spdk_bridge.v1.NVMfListenerSpec
+	(*NVMfListenerStatus)(nil),        // 3: opi_spdk_bridge.v1.NVMfListenerStatus
+	(*CreateNVMfListenerRequest)(nil), // 4: opi_spdk_bridge.v1.CreateNVMfListenerRequest
+	(*DeleteNVMfListenerRequest)(nil), // 5: opi_spdk_bridge.v1.DeleteNVMfListenerRequest
+	(*ListNVMfListenerRequest)(nil),   // 6: opi_spdk_bridge.v1.ListNVMfListenerRequest
+	(*ListNVMfListenerResponse)(nil),  // 7: opi_spdk_bridge.v1.ListNVMfListenerResponse
+	(*GetNVMfListenerRequest)(nil),    // 8: opi_spdk_bridge.v1.GetNVMfListenerRequest
+	(*_go.ObjectKey)(nil),             // 9: opi_api.common.v1.ObjectKey
+	(_go1.NvmeTransportType)(0),       // 10: opi_api.storage.v1.NvmeTransportType
+	(_go1.NvmeAddressFamily)(0),       // 11: opi_api.storage.v1.NvmeAddressFamily
+	(*emptypb.Empty)(nil),             // 12: google.protobuf.Empty
+}
+var file_nvmf_listener_proto_depIdxs = []int32{
+	2,  // 0: opi_spdk_bridge.v1.NVMfListener.spec:type_name -> opi_spdk_bridge.v1.NVMfListenerSpec
+	3,  // 1: opi_spdk_bridge.v1.NVMfListener.status:type_name -> opi_spdk_bridge.v1.NVMfListenerStatus
+	9,  // 2: opi_spdk_bridge.v1.NVMfListenerSpec.id:type_name -> opi_api.common.v1.ObjectKey
+	9,  // 3: opi_spdk_bridge.v1.NVMfListenerSpec.subsystem_id:type_name -> opi_api.common.v1.ObjectKey
+	10, // 4: opi_spdk_bridge.v1.NVMfListenerSpec.trtype:type_name -> opi_api.storage.v1.NvmeTransportType
+	11, // 5: opi_spdk_bridge.v1.NVMfListenerSpec.adrfam:type_name -> opi_api.storage.v1.NvmeAddressFamily
+	0,  // 6: opi_spdk_bridge.v1.NVMfListenerSpec.ana_state:type_name -> opi_spdk_bridge.v1.NVMfAnaState
+	0,  // 7: opi_spdk_bridge.v1.NVMfListenerStatus.ana_state:type_name -> opi_spdk_bridge.v1.NVMfAnaState
+	1,  // 8: opi_spdk_bridge.v1.CreateNVMfListenerRequest.listener:type_name -> opi_spdk_bridge.v1.NVMfListener
+	9,  // 9: opi_spdk_bridge.v1.DeleteNVMfListenerRequest.listener_id:type_name -> opi_api.common.v1.ObjectKey
+	9,  // 10: opi_spdk_bridge.v1.ListNVMfListenerRequest.subsystem_id:type_name -> opi_api.common.v1.ObjectKey
+	1,  // 11: opi_spdk_bridge.v1.ListNVMfListenerResponse.listeners:type_name -> opi_spdk_bridge.v1.NVMfListener
+	9,  // 12: opi_spdk_bridge.v1.GetNVMfListenerRequest.listener_id:type_name -> opi_api.common.v1.ObjectKey
+	4,  // 13: opi_spdk_bridge.v1.NVMfListenerService.CreateNVMfListener:input_type -> opi_spdk_bridge.v1.CreateNVMfListenerRequest
+	5,  // 14: opi_spdk_bridge.v1.NVMfListenerService.DeleteNVMfListener:input_type -> opi_spdk_bridge.v1.DeleteNVMfListenerRequest
+	6,  // 15: opi_spdk_bridge.v1.NVMfListenerService.ListNVMfListener:input_type -> opi_spdk_bridge.v1.ListNVMfListenerRequest
+	8,  // 16: opi_spdk_bridge.v1.NVMfListenerService.GetNVMfListener:input_type -> opi_spdk_bridge.v1.GetNVMfListenerRequest
+	1,  // 17: opi_spdk_bridge.v1.NVMfListenerService.CreateNVMfListener:output_type -> opi_spdk_bridge.v1.NVMfListener
+	12, // 18: opi_spdk_bridge.v1.NVMfListenerService.DeleteNVMfListener:output_type -> google.protobuf.Empty
+	7,  // 19: opi_spdk_bridge.v1.NVMfListenerService.ListNVMfListener:output_type -> opi_spdk_bridge.v1.ListNVMfListenerResponse
+	1,  // 20: opi_spdk_bridge.v1.NVMfListenerService.GetNVMfListener:output_type -> opi_spdk_bridge.v1.NVMfListener
+	17, // [17:21] is the sub-list for method output_type
+	13, // [13:17] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_nvmf_listener_proto_init() }
+func file_nvmf_listener_proto_init() {
+	if File_nvmf_listener_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nvmf_listener_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfListener); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_listener_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfListenerSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_listener_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfListenerStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_listener_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateNVMfListenerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_listener_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteNVMfListenerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_listener_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNVMfListenerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_listener_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNVMfListenerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_listener_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNVMfListenerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nvmf_listener_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nvmf_listener_proto_goTypes,
+		DependencyIndexes: file_nvmf_listener_proto_depIdxs,
+		EnumInfos:         file_nvmf_listener_proto_enumTypes,
+		MessageInfos:      file_nvmf_listener_proto_msgTypes,
+	}.Build()
+	File_nvmf_listener_proto = out.File
+	file_nvmf_listener_proto_rawDesc = nil
+	file_nvmf_listener_proto_goTypes = nil
+	file_nvmf_listener_proto_depIdxs = nil
+}
@@ -0,0 +1,214 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: nvmf_listener.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NVMfListenerServiceClient is the client API for NVMfListenerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NVMfListenerServiceClient interface {
+	CreateNVMfListener(ctx context.Context, in *CreateNVMfListenerRequest, opts ...grpc.CallOption) (*NVMfListener, error)
+	DeleteNVMfListener(ctx context.Context, in *DeleteNVMfListenerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListNVMfListener(ctx context.Context, in *ListNVMfListenerRequest, opts ...grpc.CallOption) (*ListNVMfListenerResponse, error)
+	GetNVMfListener(ctx context.Context, in *GetNVMfListenerRequest, opts ...grpc.CallOption) (*NVMfListener, error)
+}
+
+type nVMfListenerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNVMfListenerServiceClient(cc grpc.ClientConnInterface) NVMfListenerServiceClient {
+	return &nVMfListenerServiceClient{cc}
+}
+
+func (c *nVMfListenerServiceClient) CreateNVMfListener(ctx context.Context, in *CreateNVMfListenerRequest, opts ...grpc.CallOption) (*NVMfListener, error) {
+	out := new(NVMfListener)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfListenerService/CreateNVMfListener", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfListenerServiceClient) DeleteNVMfListener(ctx context.Context, in *DeleteNVMfListenerRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfListenerService/DeleteNVMfListener", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfListenerServiceClient) ListNVMfListener(ctx context.Context, in *ListNVMfListenerRequest, opts ...grpc.CallOption) (*ListNVMfListenerResponse, error) {
+	out := new(ListNVMfListenerResponse)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfListenerService/ListNVMfListener", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfListenerServiceClient) GetNVMfListener(ctx context.Context, in *GetNVMfListenerRequest, opts ...grpc.CallOption) (*NVMfListener, error) {
+	out := new(NVMfListener)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfListenerService/GetNVMfListener", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NVMfListenerServiceServer is the server API for NVMfListenerService service.
+// All implementations must embed UnimplementedNVMfListenerServiceServer
+// for forward compatibility
+type NVMfListenerServiceServer interface {
+	CreateNVMfListener(context.Context, *CreateNVMfListenerRequest) (*NVMfListener, error)
+	DeleteNVMfListener(context.Context, *DeleteNVMfListenerRequest) (*emptypb.Empty, error)
+	ListNVMfListener(context.Context, *ListNVMfListenerRequest) (*ListNVMfListenerResponse, error)
+	GetNVMfListener(context.Context, *GetNVMfListenerRequest) (*NVMfListener, error)
+	mustEmbedUnimplementedNVMfListenerServiceServer()
+}
+
+// UnimplementedNVMfListenerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNVMfListenerServiceServer struct {
+}
+
+func (UnimplementedNVMfListenerServiceServer) CreateNVMfListener(context.Context, *CreateNVMfListenerRequest) (*NVMfListener, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNVMfListener not implemented")
+}
+func (UnimplementedNVMfListenerServiceServer) DeleteNVMfListener(context.Context, *DeleteNVMfListenerRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteNVMfListener not implemented")
+}
+func (UnimplementedNVMfListenerServiceServer) ListNVMfListener(context.Context, *ListNVMfListenerRequest) (*ListNVMfListenerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNVMfListener not implemented")
+}
+func (UnimplementedNVMfListenerServiceServer) GetNVMfListener(context.Context, *GetNVMfListenerRequest) (*NVMfListener, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNVMfListener not implemented")
+}
+func (UnimplementedNVMfListenerServiceServer) mustEmbedUnimplementedNVMfListenerServiceServer() {}
+
+// UnsafeNVMfListenerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NVMfListenerServiceServer will
+// result in compilation errors.
+type UnsafeNVMfListenerServiceServer interface {
+	mustEmbedUnimplementedNVMfListenerServiceServer()
+}
+
+func RegisterNVMfListenerServiceServer(s grpc.ServiceRegistrar, srv NVMfListenerServiceServer) {
+	s.RegisterService(&NVMfListenerService_ServiceDesc, srv)
+}
+
+func _NVMfListenerService_CreateNVMfListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNVMfListenerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfListenerServiceServer).CreateNVMfListener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfListenerService/CreateNVMfListener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfListenerServiceServer).CreateNVMfListener(ctx, req.(*CreateNVMfListenerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfListenerService_DeleteNVMfListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteNVMfListenerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfListenerServiceServer).DeleteNVMfListener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfListenerService/DeleteNVMfListener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfListenerServiceServer).DeleteNVMfListener(ctx, req.(*DeleteNVMfListenerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfListenerService_ListNVMfListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNVMfListenerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfListenerServiceServer).ListNVMfListener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfListenerService/ListNVMfListener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfListenerServiceServer).ListNVMfListener(ctx, req.(*ListNVMfListenerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfListenerService_GetNVMfListener_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNVMfListenerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfListenerServiceServer).GetNVMfListener(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfListenerService/GetNVMfListener",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfListenerServiceServer).GetNVMfListener(ctx, req.(*GetNVMfListenerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NVMfListenerService_ServiceDesc is the grpc.ServiceDesc for NVMfListenerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NVMfListenerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.v1.NVMfListenerService",
+	HandlerType: (*NVMfListenerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateNVMfListener",
+			Handler:    _NVMfListenerService_CreateNVMfListener_Handler,
+		},
+		{
+			MethodName: "DeleteNVMfListener",
+			Handler:    _NVMfListenerService_DeleteNVMfListener_Handler,
+		},
+		{
+			MethodName: "ListNVMfListener",
+			Handler:    _NVMfListenerService_ListNVMfListener_Handler,
+		},
+		{
+			MethodName: "GetNVMfListener",
+			Handler:    _NVMfListenerService_GetNVMfListener_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nvmf_listener.proto",
+}
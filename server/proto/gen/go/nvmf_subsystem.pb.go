@@ -0,0 +1,368 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: nvmf_subsystem.proto
+
+package _go
+
+import (
+	_go1 "github.com/opiproject/opi-api/common/v1/gen/go"
+	_go "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A subsystem with its NVMe-oF settings, which GetNVMeSubsystem leaves out
+type NVMfSubsystem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subsystem *_go.NVMeSubsystem `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+	// range of the controller IDs SPDK assigns to the controllers of the
+	// subsystem, 1 to 65519 when both are 0
+	MinCntlid int32 `protobuf:"varint,2,opt,name=min_cntlid,json=minCntlid,proto3" json:"min_cntlid,omitempty"`
+	MaxCntlid int32 `protobuf:"varint,3,opt,name=max_cntlid,json=maxCntlid,proto3" json:"max_cntlid,omitempty"`
+	// output only, the addresses SPDK has the subsystem listening on, with
+	// the IDs of the listeners created through NVMfListenerService
+	Listeners []*NVMfListenerSpec `protobuf:"bytes,4,rep,name=listeners,proto3" json:"listeners,omitempty"`
+	// output only, which hosts may connect to the subsystem
+	Access *NVMfSubsystemAccess `protobuf:"bytes,5,opt,name=access,proto3" json:"access,omitempty"`
+}
+
+func (x *NVMfSubsystem) Reset() {
+	*x = NVMfSubsystem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_subsystem_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfSubsystem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfSubsystem) ProtoMessage() {}
+
+func (x *NVMfSubsystem) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_subsystem_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfSubsystem.ProtoReflect.Descriptor instead.
+func (*NVMfSubsystem) Descriptor() ([]byte, []int) {
+	return file_nvmf_subsystem_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NVMfSubsystem) GetSubsystem() *_go.NVMeSubsystem {
+	if x != nil {
+		return x.Subsystem
+	}
+	return nil
+}
+
+func (x *NVMfSubsystem) GetMinCntlid() int32 {
+	if x != nil {
+		return x.MinCntlid
+	}
+	return 0
+}
+
+func (x *NVMfSubsystem) GetMaxCntlid() int32 {
+	if x != nil {
+		return x.MaxCntlid
+	}
+	return 0
+}
+
+func (x *NVMfSubsystem) GetListeners() []*NVMfListenerSpec {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *NVMfSubsystem) GetAccess() *NVMfSubsystemAccess {
+	if x != nil {
+		return x.Access
+	}
+	return nil
+}
+
+type CreateNVMfSubsystemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subsystem *NVMfSubsystem `protobuf:"bytes,1,opt,name=subsystem,proto3" json:"subsystem,omitempty"`
+}
+
+func (x *CreateNVMfSubsystemRequest) Reset() {
+	*x = CreateNVMfSubsystemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_subsystem_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateNVMfSubsystemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNVMfSubsystemRequest) ProtoMessage() {}
+
+func (x *CreateNVMfSubsystemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_subsystem_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNVMfSubsystemRequest.ProtoReflect.Descriptor instead.
+func (*CreateNVMfSubsystemRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_subsystem_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateNVMfSubsystemRequest) GetSubsystem() *NVMfSubsystem {
+	if x != nil {
+		return x.Subsystem
+	}
+	return nil
+}
+
+type GetNVMfSubsystemRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubsystemId *_go1.ObjectKey `protobuf:"bytes,1,opt,name=subsystem_id,json=subsystemId,proto3" json:"subsystem_id,omitempty"`
+}
+
+func (x *GetNVMfSubsystemRequest) Reset() {
+	*x = GetNVMfSubsystemRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_subsystem_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNVMfSubsystemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNVMfSubsystemRequest) ProtoMessage() {}
+
+func (x *GetNVMfSubsystemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_subsystem_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNVMfSubsystemRequest.ProtoReflect.Descriptor instead.
+func (*GetNVMfSubsystemRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_subsystem_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetNVMfSubsystemRequest) GetSubsystemId() *_go1.ObjectKey {
+	if x != nil {
+		return x.SubsystemId
+	}
+	return nil
+}
+
+var File_nvmf_subsystem_proto protoreflect.FileDescriptor
+
+var file_nvmf_subsystem_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x6e, 0x76, 0x6d, 0x66, 0x5f, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x66, 0x72,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x5f, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x70, 0x63, 0x69, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x13, 0x6e, 0x76, 0x6d, 0x66, 0x5f, 0x6c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x6e, 0x76, 0x6d,
+	0x66, 0x5f, 0x68, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x93, 0x02, 0x0a,
+	0x0d, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x3f,
+	0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x65, 0x53, 0x75, 0x62, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x6e, 0x74, 0x6c, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x43, 0x6e, 0x74, 0x6c, 0x69, 0x64, 0x12, 0x1d,
+	0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x63, 0x6e, 0x74, 0x6c, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x43, 0x6e, 0x74, 0x6c, 0x69, 0x64, 0x12, 0x42, 0x0a,
+	0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x53, 0x70, 0x65, 0x63, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72,
+	0x73, 0x12, 0x3f, 0x0a, 0x06, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x27, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79,
+	0x73, 0x74, 0x65, 0x6d, 0x41, 0x63, 0x63, 0x65, 0x73, 0x73, 0x52, 0x06, 0x61, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x22, 0x5d, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66,
+	0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x3f, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x22, 0x5a, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x0c,
+	0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79,
+	0x52, 0x0b, 0x73, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x49, 0x64, 0x32, 0xe8, 0x01,
+	0x0a, 0x14, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6a, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x2e, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x22, 0x00, 0x12, 0x64, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x2b, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4e,
+	0x56, 0x4d, 0x66, 0x53, 0x75, 0x62, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x53, 0x75, 0x62,
+	0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x22, 0x00, 0x42, 0x1d, 0x5a, 0x1b, 0x6f, 0x70, 0x69, 0x2e,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nvmf_subsystem_proto_rawDescOnce sync.Once
+	file_nvmf_subsystem_proto_rawDescData = file_nvmf_subsystem_proto_rawDesc
+)
+
+func file_nvmf_subsystem_proto_rawDescGZIP() []byte {
+	file_nvmf_subsystem_proto_rawDescOnce.Do(func() {
+		file_nvmf_subsystem_proto_rawDescData = protoimpl.X.CompressGZIP(file_nvmf_subsystem_proto_rawDescData)
+	})
+	return file_nvmf_subsystem_proto_rawDescData
+}
+
+var file_nvmf_subsystem_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_nvmf_subsystem_proto_goTypes = []interface{}{
+	(*NVMfSubsystem)(nil),              // 0: opi_spdk_bridge.v1.NVMfSubsystem
+	(*CreateNVMfSubsystemRequest)(nil), // 1: opi_spdk_bridge.v1.CreateNVMfSubsystemRequest
+	(*GetNVMfSubsystemRequest)(nil),    // 2: opi_spdk_bridge.v1.GetNVMfSubsystemRequest
+	(*_go.NVMeSubsystem)(nil),          // 3: opi_api.storage.v1.NVMeSubsystem
+	(*NVMfListenerSpec)(nil),           // 4: opi_spdk_bridge.v1.NVMfListenerSpec
+	(*NVMfSubsystemAccess)(nil),        // 5: opi_spdk_bridge.v1.NVMfSubsystemAccess
+	(*_go1.ObjectKey)(nil),             // 6: opi_api.common.v1.ObjectKey
+}
+var file_nvmf_subsystem_proto_depIdxs = []int32{
+	3, // 0: opi_spdk_bridge.v1.NVMfSubsystem.subsystem:type_name -> opi_api.storage.v1.NVMeSubsystem
+	4, // 1: opi_spdk_bridge.v1.NVMfSubsystem.listeners:type_name -> opi_spdk_bridge.v1.NVMfListenerSpec
+	5, // 2: opi_spdk_bridge.v1.NVMfSubsystem.access:type_name -> opi_spdk_bridge.v1.NVMfSubsystemAccess
+	0, // 3: opi_spdk_bridge.v1.CreateNVMfSubsystemRequest.subsystem:type_name -> opi_spdk_bridge.v1.NVMfSubsystem
+	6, // 4: opi_spdk_bridge.v1.GetNVMfSubsystemRequest.subsystem_id:type_name -> opi_api.common.v1.ObjectKey
+	1, // 5: opi_spdk_bridge.v1.NVMfSubsystemService.CreateNVMfSubsystem:input_type -> opi_spdk_bridge.v1.CreateNVMfSubsystemRequest
+	2, // 6: opi_spdk_bridge.v1.NVMfSubsystemService.GetNVMfSubsystem:input_type -> opi_spdk_bridge.v1.GetNVMfSubsystemRequest
+	0, // 7: opi_spdk_bridge.v1.NVMfSubsystemService.CreateNVMfSubsystem:output_type -> opi_spdk_bridge.v1.NVMfSubsystem
+	0, // 8: opi_spdk_bridge.v1.NVMfSubsystemService.GetNVMfSubsystem:output_type -> opi_spdk_bridge.v1.NVMfSubsystem
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_nvmf_subsystem_proto_init() }
+func file_nvmf_subsystem_proto_init() {
+	if File_nvmf_subsystem_proto != nil {
+		return
+	}
+	file_nvmf_listener_proto_init()
+	file_nvmf_host_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_nvmf_subsystem_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfSubsystem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_subsystem_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateNVMfSubsystemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_subsystem_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNVMfSubsystemRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nvmf_subsystem_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nvmf_subsystem_proto_goTypes,
+		DependencyIndexes: file_nvmf_subsystem_proto_depIdxs,
+		MessageInfos:      file_nvmf_subsystem_proto_msgTypes,
+	}.Build()
+	File_nvmf_subsystem_proto = out.File
+	file_nvmf_subsystem_proto_rawDesc = nil
+	file_nvmf_subsystem_proto_goTypes = nil
+	file_nvmf_subsystem_proto_depIdxs = nil
+}
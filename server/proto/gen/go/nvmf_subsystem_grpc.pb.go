@@ -0,0 +1,145 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: nvmf_subsystem.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NVMfSubsystemServiceClient is the client API for NVMfSubsystemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NVMfSubsystemServiceClient interface {
+	// CreateNVMfSubsystem creates the subsystem like CreateNVMeSubsystem,
+	// with the controller ID range of the request
+	CreateNVMfSubsystem(ctx context.Context, in *CreateNVMfSubsystemRequest, opts ...grpc.CallOption) (*NVMfSubsystem, error)
+	GetNVMfSubsystem(ctx context.Context, in *GetNVMfSubsystemRequest, opts ...grpc.CallOption) (*NVMfSubsystem, error)
+}
+
+type nVMfSubsystemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNVMfSubsystemServiceClient(cc grpc.ClientConnInterface) NVMfSubsystemServiceClient {
+	return &nVMfSubsystemServiceClient{cc}
+}
+
+func (c *nVMfSubsystemServiceClient) CreateNVMfSubsystem(ctx context.Context, in *CreateNVMfSubsystemRequest, opts ...grpc.CallOption) (*NVMfSubsystem, error) {
+	out := new(NVMfSubsystem)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfSubsystemService/CreateNVMfSubsystem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfSubsystemServiceClient) GetNVMfSubsystem(ctx context.Context, in *GetNVMfSubsystemRequest, opts ...grpc.CallOption) (*NVMfSubsystem, error) {
+	out := new(NVMfSubsystem)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfSubsystemService/GetNVMfSubsystem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NVMfSubsystemServiceServer is the server API for NVMfSubsystemService service.
+// All implementations must embed UnimplementedNVMfSubsystemServiceServer
+// for forward compatibility
+type NVMfSubsystemServiceServer interface {
+	// CreateNVMfSubsystem creates the subsystem like CreateNVMeSubsystem,
+	// with the controller ID range of the request
+	CreateNVMfSubsystem(context.Context, *CreateNVMfSubsystemRequest) (*NVMfSubsystem, error)
+	GetNVMfSubsystem(context.Context, *GetNVMfSubsystemRequest) (*NVMfSubsystem, error)
+	mustEmbedUnimplementedNVMfSubsystemServiceServer()
+}
+
+// UnimplementedNVMfSubsystemServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNVMfSubsystemServiceServer struct {
+}
+
+func (UnimplementedNVMfSubsystemServiceServer) CreateNVMfSubsystem(context.Context, *CreateNVMfSubsystemRequest) (*NVMfSubsystem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNVMfSubsystem not implemented")
+}
+func (UnimplementedNVMfSubsystemServiceServer) GetNVMfSubsystem(context.Context, *GetNVMfSubsystemRequest) (*NVMfSubsystem, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNVMfSubsystem not implemented")
+}
+func (UnimplementedNVMfSubsystemServiceServer) mustEmbedUnimplementedNVMfSubsystemServiceServer() {}
+
+// UnsafeNVMfSubsystemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NVMfSubsystemServiceServer will
+// result in compilation errors.
+type UnsafeNVMfSubsystemServiceServer interface {
+	mustEmbedUnimplementedNVMfSubsystemServiceServer()
+}
+
+func RegisterNVMfSubsystemServiceServer(s grpc.ServiceRegistrar, srv NVMfSubsystemServiceServer) {
+	s.RegisterService(&NVMfSubsystemService_ServiceDesc, srv)
+}
+
+func _NVMfSubsystemService_CreateNVMfSubsystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNVMfSubsystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfSubsystemServiceServer).CreateNVMfSubsystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfSubsystemService/CreateNVMfSubsystem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfSubsystemServiceServer).CreateNVMfSubsystem(ctx, req.(*CreateNVMfSubsystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfSubsystemService_GetNVMfSubsystem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNVMfSubsystemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfSubsystemServiceServer).GetNVMfSubsystem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfSubsystemService/GetNVMfSubsystem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfSubsystemServiceServer).GetNVMfSubsystem(ctx, req.(*GetNVMfSubsystemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NVMfSubsystemService_ServiceDesc is the grpc.ServiceDesc for NVMfSubsystemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NVMfSubsystemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.v1.NVMfSubsystemService",
+	HandlerType: (*NVMfSubsystemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateNVMfSubsystem",
+			Handler:    _NVMfSubsystemService_CreateNVMfSubsystem_Handler,
+		},
+		{
+			MethodName: "GetNVMfSubsystem",
+			Handler:    _NVMfSubsystemService_GetNVMfSubsystem_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nvmf_subsystem.proto",
+}
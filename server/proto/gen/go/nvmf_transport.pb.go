@@ -0,0 +1,582 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: nvmf_transport.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/common/v1/gen/go"
+	_go1 "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type NVMfTransport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spec *NVMfTransportSpec `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+}
+
+func (x *NVMfTransport) Reset() {
+	*x = NVMfTransport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_transport_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfTransport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfTransport) ProtoMessage() {}
+
+func (x *NVMfTransport) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_transport_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfTransport.ProtoReflect.Descriptor instead.
+func (*NVMfTransport) Descriptor() ([]byte, []int) {
+	return file_nvmf_transport_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NVMfTransport) GetSpec() *NVMfTransportSpec {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+// Parameters left at 0 get SPDK's defaults for the transport type
+type NVMfTransportSpec struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// object's unique identifier
+	Id *_go.ObjectKey `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// transport type, at most one transport per type,
+	// NVME_TRANSPORT_PCIE for vfio-user
+	Trtype _go1.NvmeTransportType `protobuf:"varint,2,opt,name=trtype,proto3,enum=opi_api.storage.v1.NvmeTransportType" json:"trtype,omitempty"`
+	// I/O unit size in bytes
+	IoUnitSize int32 `protobuf:"varint,3,opt,name=io_unit_size,json=ioUnitSize,proto3" json:"io_unit_size,omitempty"`
+	// queue pairs per controller, including the admin queue pair
+	MaxQpairsPerCtrlr int32 `protobuf:"varint,4,opt,name=max_qpairs_per_ctrlr,json=maxQpairsPerCtrlr,proto3" json:"max_qpairs_per_ctrlr,omitempty"`
+	// in-capsule data size in bytes, which may be 0
+	InCapsuleDataSize *int32 `protobuf:"varint,5,opt,name=in_capsule_data_size,json=inCapsuleDataSize,proto3,oneof" json:"in_capsule_data_size,omitempty"`
+	// pooled data buffers
+	NumSharedBuffers int32 `protobuf:"varint,6,opt,name=num_shared_buffers,json=numSharedBuffers,proto3" json:"num_shared_buffers,omitempty"`
+	// priority of the sockets, TCP only
+	SockPriority int32 `protobuf:"varint,7,opt,name=sock_priority,json=sockPriority,proto3" json:"sock_priority,omitempty"`
+}
+
+func (x *NVMfTransportSpec) Reset() {
+	*x = NVMfTransportSpec{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_transport_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NVMfTransportSpec) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NVMfTransportSpec) ProtoMessage() {}
+
+func (x *NVMfTransportSpec) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_transport_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NVMfTransportSpec.ProtoReflect.Descriptor instead.
+func (*NVMfTransportSpec) Descriptor() ([]byte, []int) {
+	return file_nvmf_transport_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NVMfTransportSpec) GetId() *_go.ObjectKey {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *NVMfTransportSpec) GetTrtype() _go1.NvmeTransportType {
+	if x != nil {
+		return x.Trtype
+	}
+	return _go1.NvmeTransportType(0)
+}
+
+func (x *NVMfTransportSpec) GetIoUnitSize() int32 {
+	if x != nil {
+		return x.IoUnitSize
+	}
+	return 0
+}
+
+func (x *NVMfTransportSpec) GetMaxQpairsPerCtrlr() int32 {
+	if x != nil {
+		return x.MaxQpairsPerCtrlr
+	}
+	return 0
+}
+
+func (x *NVMfTransportSpec) GetInCapsuleDataSize() int32 {
+	if x != nil && x.InCapsuleDataSize != nil {
+		return *x.InCapsuleDataSize
+	}
+	return 0
+}
+
+func (x *NVMfTransportSpec) GetNumSharedBuffers() int32 {
+	if x != nil {
+		return x.NumSharedBuffers
+	}
+	return 0
+}
+
+func (x *NVMfTransportSpec) GetSockPriority() int32 {
+	if x != nil {
+		return x.SockPriority
+	}
+	return 0
+}
+
+type CreateNVMfTransportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transport *NVMfTransport `protobuf:"bytes,1,opt,name=transport,proto3" json:"transport,omitempty"`
+}
+
+func (x *CreateNVMfTransportRequest) Reset() {
+	*x = CreateNVMfTransportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_transport_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateNVMfTransportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateNVMfTransportRequest) ProtoMessage() {}
+
+func (x *CreateNVMfTransportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_transport_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateNVMfTransportRequest.ProtoReflect.Descriptor instead.
+func (*CreateNVMfTransportRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_transport_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateNVMfTransportRequest) GetTransport() *NVMfTransport {
+	if x != nil {
+		return x.Transport
+	}
+	return nil
+}
+
+type ListNVMfTransportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListNVMfTransportRequest) Reset() {
+	*x = ListNVMfTransportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_transport_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNVMfTransportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNVMfTransportRequest) ProtoMessage() {}
+
+func (x *ListNVMfTransportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_transport_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNVMfTransportRequest.ProtoReflect.Descriptor instead.
+func (*ListNVMfTransportRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_transport_proto_rawDescGZIP(), []int{3}
+}
+
+type ListNVMfTransportResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Transports []*NVMfTransport `protobuf:"bytes,1,rep,name=transports,proto3" json:"transports,omitempty"`
+}
+
+func (x *ListNVMfTransportResponse) Reset() {
+	*x = ListNVMfTransportResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_transport_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListNVMfTransportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNVMfTransportResponse) ProtoMessage() {}
+
+func (x *ListNVMfTransportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_transport_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNVMfTransportResponse.ProtoReflect.Descriptor instead.
+func (*ListNVMfTransportResponse) Descriptor() ([]byte, []int) {
+	return file_nvmf_transport_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListNVMfTransportResponse) GetTransports() []*NVMfTransport {
+	if x != nil {
+		return x.Transports
+	}
+	return nil
+}
+
+type GetNVMfTransportRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransportId *_go.ObjectKey `protobuf:"bytes,1,opt,name=transport_id,json=transportId,proto3" json:"transport_id,omitempty"`
+}
+
+func (x *GetNVMfTransportRequest) Reset() {
+	*x = GetNVMfTransportRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_nvmf_transport_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetNVMfTransportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNVMfTransportRequest) ProtoMessage() {}
+
+func (x *GetNVMfTransportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_nvmf_transport_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNVMfTransportRequest.ProtoReflect.Descriptor instead.
+func (*GetNVMfTransportRequest) Descriptor() ([]byte, []int) {
+	return file_nvmf_transport_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetNVMfTransportRequest) GetTransportId() *_go.ObjectKey {
+	if x != nil {
+		return x.TransportId
+	}
+	return nil
+}
+
+var File_nvmf_transport_proto protoreflect.FileDescriptor
+
+var file_nvmf_transport_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x6e, 0x76, 0x6d, 0x66, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x62, 0x61,
+	0x63, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x6e, 0x76, 0x6d, 0x65, 0x5f, 0x74, 0x63, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4a, 0x0a, 0x0d, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x39, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63,
+	0x22, 0xf5, 0x02, 0x0a, 0x11, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x2c, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x3d, 0x0a, 0x06, 0x74, 0x72, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x73,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x76, 0x6d, 0x65, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x74, 0x72, 0x74,
+	0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x69, 0x6f, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x69, 0x6f, 0x55, 0x6e, 0x69,
+	0x74, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x2f, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x71, 0x70, 0x61,
+	0x69, 0x72, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x63, 0x74, 0x72, 0x6c, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x51, 0x70, 0x61, 0x69, 0x72, 0x73, 0x50, 0x65,
+	0x72, 0x43, 0x74, 0x72, 0x6c, 0x72, 0x12, 0x34, 0x0a, 0x14, 0x69, 0x6e, 0x5f, 0x63, 0x61, 0x70,
+	0x73, 0x75, 0x6c, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x11, 0x69, 0x6e, 0x43, 0x61, 0x70, 0x73, 0x75, 0x6c,
+	0x65, 0x44, 0x61, 0x74, 0x61, 0x53, 0x69, 0x7a, 0x65, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x12,
+	0x6e, 0x75, 0x6d, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x6e, 0x75, 0x6d, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x6f,
+	0x63, 0x6b, 0x5f, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x73, 0x6f, 0x63, 0x6b, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x42,
+	0x17, 0x0a, 0x15, 0x5f, 0x69, 0x6e, 0x5f, 0x63, 0x61, 0x70, 0x73, 0x75, 0x6c, 0x65, 0x5f, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x22, 0x5d, 0x0a, 0x1a, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70,
+	0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x09, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x1a, 0x0a, 0x18, 0x4c, 0x69, 0x73, 0x74, 0x4e,
+	0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x5e, 0x0a, 0x19, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x41, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x73, 0x22, 0x5a, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x3f,
+	0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63,
+	0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b,
+	0x65, 0x79, 0x52, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x64, 0x32,
+	0xdc, 0x02, 0x0a, 0x14, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x6a, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x2e, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4e, 0x56, 0x4d, 0x66, 0x54,
+	0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x22, 0x00, 0x12, 0x72, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2c, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2d, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70,
+	0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4e,
+	0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x2b, 0x2e, 0x6f,
+	0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4e,
+	0x56, 0x4d, 0x66, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x42, 0x1d,
+	0x5a, 0x1b, 0x6f, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_nvmf_transport_proto_rawDescOnce sync.Once
+	file_nvmf_transport_proto_rawDescData = file_nvmf_transport_proto_rawDesc
+)
+
+func file_nvmf_transport_proto_rawDescGZIP() []byte {
+	file_nvmf_transport_proto_rawDescOnce.Do(func() {
+		file_nvmf_transport_proto_rawDescData = protoimpl.X.CompressGZIP(file_nvmf_transport_proto_rawDescData)
+	})
+	return file_nvmf_transport_proto_rawDescData
+}
+
+var file_nvmf_transport_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_nvmf_transport_proto_goTypes = []interface{}{
+	(*NVMfTransport)(nil),              // 0: opi_spdk_bridge.v1.NVMfTransport
+	(*NVMfTransportSpec)(nil),          // 1: opi_spdk_bridge.v1.NVMfTransportSpec
+	(*CreateNVMfTransportRequest)(nil), // 2: opi_spdk_bridge.v1.CreateNVMfTransportRequest
+	(*ListNVMfTransportRequest)(nil),   // 3: opi_spdk_bridge.v1.ListNVMfTransportRequest
+	(*ListNVMfTransportResponse)(nil),  // 4: opi_spdk_bridge.v1.ListNVMfTransportResponse
+	(*GetNVMfTransportRequest)(nil),    // 5: opi_spdk_bridge.v1.GetNVMfTransportRequest
+	(*_go.ObjectKey)(nil),              // 6: opi_api.common.v1.ObjectKey
+	(_go1.NvmeTransportType)(0),        // 7: opi_api.storage.v1.NvmeTransportType
+}
+var file_nvmf_transport_proto_depIdxs = []int32{
+	1, // 0: opi_spdk_bridge.v1.NVMfTransport.spec:type_name -> opi_spdk_bridge.v1.NVMfTransportSpec
+	6, // 1: opi_spdk_bridge.v1.NVMfTransportSpec.id:type_name -> opi_api.common.v1.ObjectKey
+	7, // 2: opi_spdk_bridge.v1.NVMfTransportSpec.trtype:type_name -> opi_api.storage.v1.NvmeTransportType
+	0, // 3: opi_spdk_bridge.v1.CreateNVMfTransportRequest.transport:type_name -> opi_spdk_bridge.v1.NVMfTransport
+	0, // 4: opi_spdk_bridge.v1.ListNVMfTransportResponse.transports:type_name -> opi_spdk_bridge.v1.NVMfTransport
+	6, // 5: opi_spdk_bridge.v1.GetNVMfTransportRequest.transport_id:type_name -> opi_api.common.v1.ObjectKey
+	2, // 6: opi_spdk_bridge.v1.NVMfTransportService.CreateNVMfTransport:input_type -> opi_spdk_bridge.v1.CreateNVMfTransportRequest
+	3, // 7: opi_spdk_bridge.v1.NVMfTransportService.ListNVMfTransport:input_type -> opi_spdk_bridge.v1.ListNVMfTransportRequest
+	5, // 8: opi_spdk_bridge.v1.NVMfTransportService.GetNVMfTransport:input_type -> opi_spdk_bridge.v1.GetNVMfTransportRequest
+	0, // 9: opi_spdk_bridge.v1.NVMfTransportService.CreateNVMfTransport:output_type -> opi_spdk_bridge.v1.NVMfTransport
+	4, // 10: opi_spdk_bridge.v1.NVMfTransportService.ListNVMfTransport:output_type -> opi_spdk_bridge.v1.ListNVMfTransportResponse
+	0, // 11: opi_spdk_bridge.v1.NVMfTransportService.GetNVMfTransport:output_type -> opi_spdk_bridge.v1.NVMfTransport
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_nvmf_transport_proto_init() }
+func file_nvmf_transport_proto_init() {
+	if File_nvmf_transport_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_nvmf_transport_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfTransport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_transport_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NVMfTransportSpec); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_transport_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateNVMfTransportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_transport_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNVMfTransportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_transport_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListNVMfTransportResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_nvmf_transport_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNVMfTransportRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_nvmf_transport_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_nvmf_transport_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_nvmf_transport_proto_goTypes,
+		DependencyIndexes: file_nvmf_transport_proto_depIdxs,
+		MessageInfos:      file_nvmf_transport_proto_msgTypes,
+	}.Build()
+	File_nvmf_transport_proto = out.File
+	file_nvmf_transport_proto_rawDesc = nil
+	file_nvmf_transport_proto_goTypes = nil
+	file_nvmf_transport_proto_depIdxs = nil
+}
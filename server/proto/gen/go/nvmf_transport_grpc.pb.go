@@ -0,0 +1,177 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: nvmf_transport.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// NVMfTransportServiceClient is the client API for NVMfTransportService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type NVMfTransportServiceClient interface {
+	CreateNVMfTransport(ctx context.Context, in *CreateNVMfTransportRequest, opts ...grpc.CallOption) (*NVMfTransport, error)
+	ListNVMfTransport(ctx context.Context, in *ListNVMfTransportRequest, opts ...grpc.CallOption) (*ListNVMfTransportResponse, error)
+	GetNVMfTransport(ctx context.Context, in *GetNVMfTransportRequest, opts ...grpc.CallOption) (*NVMfTransport, error)
+}
+
+type nVMfTransportServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewNVMfTransportServiceClient(cc grpc.ClientConnInterface) NVMfTransportServiceClient {
+	return &nVMfTransportServiceClient{cc}
+}
+
+func (c *nVMfTransportServiceClient) CreateNVMfTransport(ctx context.Context, in *CreateNVMfTransportRequest, opts ...grpc.CallOption) (*NVMfTransport, error) {
+	out := new(NVMfTransport)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfTransportService/CreateNVMfTransport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfTransportServiceClient) ListNVMfTransport(ctx context.Context, in *ListNVMfTransportRequest, opts ...grpc.CallOption) (*ListNVMfTransportResponse, error) {
+	out := new(ListNVMfTransportResponse)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfTransportService/ListNVMfTransport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *nVMfTransportServiceClient) GetNVMfTransport(ctx context.Context, in *GetNVMfTransportRequest, opts ...grpc.CallOption) (*NVMfTransport, error) {
+	out := new(NVMfTransport)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.NVMfTransportService/GetNVMfTransport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// NVMfTransportServiceServer is the server API for NVMfTransportService service.
+// All implementations must embed UnimplementedNVMfTransportServiceServer
+// for forward compatibility
+type NVMfTransportServiceServer interface {
+	CreateNVMfTransport(context.Context, *CreateNVMfTransportRequest) (*NVMfTransport, error)
+	ListNVMfTransport(context.Context, *ListNVMfTransportRequest) (*ListNVMfTransportResponse, error)
+	GetNVMfTransport(context.Context, *GetNVMfTransportRequest) (*NVMfTransport, error)
+	mustEmbedUnimplementedNVMfTransportServiceServer()
+}
+
+// UnimplementedNVMfTransportServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedNVMfTransportServiceServer struct {
+}
+
+func (UnimplementedNVMfTransportServiceServer) CreateNVMfTransport(context.Context, *CreateNVMfTransportRequest) (*NVMfTransport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateNVMfTransport not implemented")
+}
+func (UnimplementedNVMfTransportServiceServer) ListNVMfTransport(context.Context, *ListNVMfTransportRequest) (*ListNVMfTransportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNVMfTransport not implemented")
+}
+func (UnimplementedNVMfTransportServiceServer) GetNVMfTransport(context.Context, *GetNVMfTransportRequest) (*NVMfTransport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNVMfTransport not implemented")
+}
+func (UnimplementedNVMfTransportServiceServer) mustEmbedUnimplementedNVMfTransportServiceServer() {}
+
+// UnsafeNVMfTransportServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to NVMfTransportServiceServer will
+// result in compilation errors.
+type UnsafeNVMfTransportServiceServer interface {
+	mustEmbedUnimplementedNVMfTransportServiceServer()
+}
+
+func RegisterNVMfTransportServiceServer(s grpc.ServiceRegistrar, srv NVMfTransportServiceServer) {
+	s.RegisterService(&NVMfTransportService_ServiceDesc, srv)
+}
+
+func _NVMfTransportService_CreateNVMfTransport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateNVMfTransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfTransportServiceServer).CreateNVMfTransport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfTransportService/CreateNVMfTransport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfTransportServiceServer).CreateNVMfTransport(ctx, req.(*CreateNVMfTransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfTransportService_ListNVMfTransport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNVMfTransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfTransportServiceServer).ListNVMfTransport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfTransportService/ListNVMfTransport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfTransportServiceServer).ListNVMfTransport(ctx, req.(*ListNVMfTransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _NVMfTransportService_GetNVMfTransport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNVMfTransportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NVMfTransportServiceServer).GetNVMfTransport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.NVMfTransportService/GetNVMfTransport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NVMfTransportServiceServer).GetNVMfTransport(ctx, req.(*GetNVMfTransportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// NVMfTransportService_ServiceDesc is the grpc.ServiceDesc for NVMfTransportService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var NVMfTransportService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.v1.NVMfTransportService",
+	HandlerType: (*NVMfTransportServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateNVMfTransport",
+			Handler:    _NVMfTransportService_CreateNVMfTransport_Handler,
+		},
+		{
+			MethodName: "ListNVMfTransport",
+			Handler:    _NVMfTransportService_ListNVMfTransport_Handler,
+		},
+		{
+			MethodName: "GetNVMfTransport",
+			Handler:    _NVMfTransportService_GetNVMfTransport_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "nvmf_transport.proto",
+}
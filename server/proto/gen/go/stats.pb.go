@@ -0,0 +1,586 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: stats.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/common/v1/gen/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WatchStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// NVMe controller, NVMe namespace, or bdev of a volume
+	Id *_go.ObjectKey `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// rounded up to a multiple of the bridge's sampling resolution, which
+	// is also the interval if unset
+	Interval *durationpb.Duration `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *WatchStatsRequest) Reset() {
+	*x = WatchStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stats_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchStatsRequest) ProtoMessage() {}
+
+func (x *WatchStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchStatsRequest.ProtoReflect.Descriptor instead.
+func (*WatchStatsRequest) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WatchStatsRequest) GetId() *_go.ObjectKey {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *WatchStatsRequest) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type StatsSample struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Time *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"`
+	// Types that are assignable to Stats:
+	//	*StatsSample_Volume
+	//	*StatsSample_Controller
+	Stats isStatsSample_Stats `protobuf_oneof:"stats"`
+}
+
+func (x *StatsSample) Reset() {
+	*x = StatsSample{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stats_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatsSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatsSample) ProtoMessage() {}
+
+func (x *StatsSample) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatsSample.ProtoReflect.Descriptor instead.
+func (*StatsSample) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StatsSample) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (m *StatsSample) GetStats() isStatsSample_Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (x *StatsSample) GetVolume() *VolumeStats {
+	if x, ok := x.GetStats().(*StatsSample_Volume); ok {
+		return x.Volume
+	}
+	return nil
+}
+
+func (x *StatsSample) GetController() *ControllerStats {
+	if x, ok := x.GetStats().(*StatsSample_Controller); ok {
+		return x.Controller
+	}
+	return nil
+}
+
+type isStatsSample_Stats interface {
+	isStatsSample_Stats()
+}
+
+type StatsSample_Volume struct {
+	Volume *VolumeStats `protobuf:"bytes,2,opt,name=volume,proto3,oneof"`
+}
+
+type StatsSample_Controller struct {
+	Controller *ControllerStats `protobuf:"bytes,3,opt,name=controller,proto3,oneof"`
+}
+
+func (*StatsSample_Volume) isStatsSample_Stats() {}
+
+func (*StatsSample_Controller) isStatsSample_Stats() {}
+
+// Queue pairs of an NVMe controller, next to the counters of SPDK's poll
+// groups, which it keeps for the whole target
+type ControllerStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ControllerId *_go.ObjectKey `protobuf:"bytes,1,opt,name=controller_id,json=controllerId,proto3" json:"controller_id,omitempty"`
+	// queue pairs connected to the controller's endpoint
+	AdminQpairs int32             `protobuf:"varint,2,opt,name=admin_qpairs,json=adminQpairs,proto3" json:"admin_qpairs,omitempty"`
+	IoQpairs    int32             `protobuf:"varint,3,opt,name=io_qpairs,json=ioQpairs,proto3" json:"io_qpairs,omitempty"`
+	PollGroups  []*PollGroupStats `protobuf:"bytes,4,rep,name=poll_groups,json=pollGroups,proto3" json:"poll_groups,omitempty"`
+	// time between this sample and the one before, unset for the first
+	// sample, whose deltas are since SPDK started
+	Interval *durationpb.Duration `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *ControllerStats) Reset() {
+	*x = ControllerStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stats_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControllerStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControllerStats) ProtoMessage() {}
+
+func (x *ControllerStats) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControllerStats.ProtoReflect.Descriptor instead.
+func (*ControllerStats) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ControllerStats) GetControllerId() *_go.ObjectKey {
+	if x != nil {
+		return x.ControllerId
+	}
+	return nil
+}
+
+func (x *ControllerStats) GetAdminQpairs() int32 {
+	if x != nil {
+		return x.AdminQpairs
+	}
+	return 0
+}
+
+func (x *ControllerStats) GetIoQpairs() int32 {
+	if x != nil {
+		return x.IoQpairs
+	}
+	return 0
+}
+
+func (x *ControllerStats) GetPollGroups() []*PollGroupStats {
+	if x != nil {
+		return x.PollGroups
+	}
+	return nil
+}
+
+func (x *ControllerStats) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+type PollGroupStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// queue pairs the poll group has served since SPDK started
+	AdminQpairs int64 `protobuf:"varint,2,opt,name=admin_qpairs,json=adminQpairs,proto3" json:"admin_qpairs,omitempty"`
+	IoQpairs    int64 `protobuf:"varint,3,opt,name=io_qpairs,json=ioQpairs,proto3" json:"io_qpairs,omitempty"`
+	// queue pairs it serves now
+	CurrentAdminQpairs int64 `protobuf:"varint,4,opt,name=current_admin_qpairs,json=currentAdminQpairs,proto3" json:"current_admin_qpairs,omitempty"`
+	CurrentIoQpairs    int64 `protobuf:"varint,5,opt,name=current_io_qpairs,json=currentIoQpairs,proto3" json:"current_io_qpairs,omitempty"`
+	PendingBdevIo      int64 `protobuf:"varint,6,opt,name=pending_bdev_io,json=pendingBdevIo,proto3" json:"pending_bdev_io,omitempty"`
+	// queue pairs it took on within the interval, and per second
+	AdminQpairsDelta int64   `protobuf:"varint,7,opt,name=admin_qpairs_delta,json=adminQpairsDelta,proto3" json:"admin_qpairs_delta,omitempty"`
+	IoQpairsDelta    int64   `protobuf:"varint,8,opt,name=io_qpairs_delta,json=ioQpairsDelta,proto3" json:"io_qpairs_delta,omitempty"`
+	AdminQpairRate   float64 `protobuf:"fixed64,9,opt,name=admin_qpair_rate,json=adminQpairRate,proto3" json:"admin_qpair_rate,omitempty"`
+	IoQpairRate      float64 `protobuf:"fixed64,10,opt,name=io_qpair_rate,json=ioQpairRate,proto3" json:"io_qpair_rate,omitempty"`
+}
+
+func (x *PollGroupStats) Reset() {
+	*x = PollGroupStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_stats_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PollGroupStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollGroupStats) ProtoMessage() {}
+
+func (x *PollGroupStats) ProtoReflect() protoreflect.Message {
+	mi := &file_stats_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollGroupStats.ProtoReflect.Descriptor instead.
+func (*PollGroupStats) Descriptor() ([]byte, []int) {
+	return file_stats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PollGroupStats) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PollGroupStats) GetAdminQpairs() int64 {
+	if x != nil {
+		return x.AdminQpairs
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetIoQpairs() int64 {
+	if x != nil {
+		return x.IoQpairs
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetCurrentAdminQpairs() int64 {
+	if x != nil {
+		return x.CurrentAdminQpairs
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetCurrentIoQpairs() int64 {
+	if x != nil {
+		return x.CurrentIoQpairs
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetPendingBdevIo() int64 {
+	if x != nil {
+		return x.PendingBdevIo
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetAdminQpairsDelta() int64 {
+	if x != nil {
+		return x.AdminQpairsDelta
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetIoQpairsDelta() int64 {
+	if x != nil {
+		return x.IoQpairsDelta
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetAdminQpairRate() float64 {
+	if x != nil {
+		return x.AdminQpairRate
+	}
+	return 0
+}
+
+func (x *PollGroupStats) GetIoQpairRate() float64 {
+	if x != nil {
+		return x.IoQpairRate
+	}
+	return 0
+}
+
+var File_stats_proto protoreflect.FileDescriptor
+
+var file_stats_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f,
+	0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76,
+	0x31, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x78, 0x0a, 0x11, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62,
+	0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x02, 0x69, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x22, 0xc8, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x39, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72,
+	0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x45, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69,
+	0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x6c, 0x65, 0x72, 0x42, 0x07, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0x90, 0x02,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x12, 0x41, 0x0a, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a,
+	0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x71, 0x70,
+	0x61, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x61, 0x64, 0x6d, 0x69,
+	0x6e, 0x51, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6f, 0x5f, 0x71, 0x70,
+	0x61, 0x69, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x69, 0x6f, 0x51, 0x70,
+	0x61, 0x69, 0x72, 0x73, 0x12, 0x43, 0x0a, 0x0b, 0x70, 0x6f, 0x6c, 0x6c, 0x5f, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6f, 0x70, 0x69, 0x5f,
+	0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x6f, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x70,
+	0x6f, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x22, 0x8e, 0x03, 0x0a, 0x0e, 0x50, 0x6f, 0x6c, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x5f, 0x71, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x51, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x69, 0x6f,
+	0x5f, 0x71, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69,
+	0x6f, 0x51, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x75, 0x72, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x71, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x64,
+	0x6d, 0x69, 0x6e, 0x51, 0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6f, 0x5f, 0x71, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x6f, 0x51,
+	0x70, 0x61, 0x69, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x5f, 0x62, 0x64, 0x65, 0x76, 0x5f, 0x69, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x64, 0x65, 0x76, 0x49, 0x6f, 0x12, 0x2c, 0x0a,
+	0x12, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x71, 0x70, 0x61, 0x69, 0x72, 0x73, 0x5f, 0x64, 0x65,
+	0x6c, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x61, 0x64, 0x6d, 0x69, 0x6e,
+	0x51, 0x70, 0x61, 0x69, 0x72, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x26, 0x0a, 0x0f, 0x69,
+	0x6f, 0x5f, 0x71, 0x70, 0x61, 0x69, 0x72, 0x73, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x69, 0x6f, 0x51, 0x70, 0x61, 0x69, 0x72, 0x73, 0x44, 0x65,
+	0x6c, 0x74, 0x61, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x5f, 0x71, 0x70, 0x61,
+	0x69, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x51, 0x70, 0x61, 0x69, 0x72, 0x52, 0x61, 0x74, 0x65, 0x12, 0x22, 0x0a,
+	0x0d, 0x69, 0x6f, 0x5f, 0x71, 0x70, 0x61, 0x69, 0x72, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x69, 0x6f, 0x51, 0x70, 0x61, 0x69, 0x72, 0x52, 0x61, 0x74,
+	0x65, 0x32, 0x68, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x58, 0x0a, 0x0a, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x25, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64,
+	0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x1d, 0x5a, 0x1b, 0x6f,
+	0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_stats_proto_rawDescOnce sync.Once
+	file_stats_proto_rawDescData = file_stats_proto_rawDesc
+)
+
+func file_stats_proto_rawDescGZIP() []byte {
+	file_stats_proto_rawDescOnce.Do(func() {
+		file_stats_proto_rawDescData = protoimpl.X.CompressGZIP(file_stats_proto_rawDescData)
+	})
+	return file_stats_proto_rawDescData
+}
+
+var file_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_stats_proto_goTypes = []interface{}{
+	(*WatchStatsRequest)(nil),     // 0: opi_spdk_bridge.v1.WatchStatsRequest
+	(*StatsSample)(nil),           // 1: opi_spdk_bridge.v1.StatsSample
+	(*ControllerStats)(nil),       // 2: opi_spdk_bridge.v1.ControllerStats
+	(*PollGroupStats)(nil),        // 3: opi_spdk_bridge.v1.PollGroupStats
+	(*_go.ObjectKey)(nil),         // 4: opi_api.common.v1.ObjectKey
+	(*durationpb.Duration)(nil),   // 5: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+	(*VolumeStats)(nil),           // 7: opi_spdk_bridge.v1.VolumeStats
+}
+var file_stats_proto_depIdxs = []int32{
+	4, // 0: opi_spdk_bridge.v1.WatchStatsRequest.id:type_name -> opi_api.common.v1.ObjectKey
+	5, // 1: opi_spdk_bridge.v1.WatchStatsRequest.interval:type_name -> google.protobuf.Duration
+	6, // 2: opi_spdk_bridge.v1.StatsSample.time:type_name -> google.protobuf.Timestamp
+	7, // 3: opi_spdk_bridge.v1.StatsSample.volume:type_name -> opi_spdk_bridge.v1.VolumeStats
+	2, // 4: opi_spdk_bridge.v1.StatsSample.controller:type_name -> opi_spdk_bridge.v1.ControllerStats
+	4, // 5: opi_spdk_bridge.v1.ControllerStats.controller_id:type_name -> opi_api.common.v1.ObjectKey
+	3, // 6: opi_spdk_bridge.v1.ControllerStats.poll_groups:type_name -> opi_spdk_bridge.v1.PollGroupStats
+	5, // 7: opi_spdk_bridge.v1.ControllerStats.interval:type_name -> google.protobuf.Duration
+	0, // 8: opi_spdk_bridge.v1.StatsService.WatchStats:input_type -> opi_spdk_bridge.v1.WatchStatsRequest
+	1, // 9: opi_spdk_bridge.v1.StatsService.WatchStats:output_type -> opi_spdk_bridge.v1.StatsSample
+	9, // [9:10] is the sub-list for method output_type
+	8, // [8:9] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_stats_proto_init() }
+func file_stats_proto_init() {
+	if File_stats_proto != nil {
+		return
+	}
+	file_volume_stats_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_stats_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stats_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatsSample); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stats_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControllerStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_stats_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PollGroupStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_stats_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*StatsSample_Volume)(nil),
+		(*StatsSample_Controller)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_stats_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_stats_proto_goTypes,
+		DependencyIndexes: file_stats_proto_depIdxs,
+		MessageInfos:      file_stats_proto_msgTypes,
+	}.Build()
+	File_stats_proto = out.File
+	file_stats_proto_rawDesc = nil
+	file_stats_proto_goTypes = nil
+	file_stats_proto_depIdxs = nil
+}
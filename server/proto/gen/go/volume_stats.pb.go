@@ -0,0 +1,438 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.19.4
+// source: volume_stats.proto
+
+package _go
+
+import (
+	_go "github.com/opiproject/opi-api/common/v1/gen/go"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetVolumeStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// NVMe namespace, or bdev of a volume
+	Id *_go.ObjectKey `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// a sample of the same volume GetVolumeStats returned before, which the
+	// rates and deltas are over; since SPDK started if unset
+	Previous *VolumeStats `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"`
+}
+
+func (x *GetVolumeStatsRequest) Reset() {
+	*x = GetVolumeStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_volume_stats_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVolumeStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVolumeStatsRequest) ProtoMessage() {}
+
+func (x *GetVolumeStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_volume_stats_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVolumeStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetVolumeStatsRequest) Descriptor() ([]byte, []int) {
+	return file_volume_stats_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetVolumeStatsRequest) GetId() *_go.ObjectKey {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *GetVolumeStatsRequest) GetPrevious() *VolumeStats {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+// I/O statistics of a volume. The counters add up since the volume was
+// created, the rates are over the interval since the sample before.
+type VolumeStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VolumeId *_go.ObjectKey `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+	Read     *IoStats       `protobuf:"bytes,2,opt,name=read,proto3" json:"read,omitempty"`
+	Write    *IoStats       `protobuf:"bytes,3,opt,name=write,proto3" json:"write,omitempty"`
+	Unmap    *IoStats       `protobuf:"bytes,4,opt,name=unmap,proto3" json:"unmap,omitempty"`
+	// time between this sample and the one the rates are computed from,
+	// since SPDK started for the first sample of a volume
+	Interval *durationpb.Duration `protobuf:"bytes,5,opt,name=interval,proto3" json:"interval,omitempty"`
+	// SPDK's clock when the sample was taken, which the interval of the next
+	// sample starts from
+	Ticks int64 `protobuf:"varint,6,opt,name=ticks,proto3" json:"ticks,omitempty"`
+}
+
+func (x *VolumeStats) Reset() {
+	*x = VolumeStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_volume_stats_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeStats) ProtoMessage() {}
+
+func (x *VolumeStats) ProtoReflect() protoreflect.Message {
+	mi := &file_volume_stats_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeStats.ProtoReflect.Descriptor instead.
+func (*VolumeStats) Descriptor() ([]byte, []int) {
+	return file_volume_stats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VolumeStats) GetVolumeId() *_go.ObjectKey {
+	if x != nil {
+		return x.VolumeId
+	}
+	return nil
+}
+
+func (x *VolumeStats) GetRead() *IoStats {
+	if x != nil {
+		return x.Read
+	}
+	return nil
+}
+
+func (x *VolumeStats) GetWrite() *IoStats {
+	if x != nil {
+		return x.Write
+	}
+	return nil
+}
+
+func (x *VolumeStats) GetUnmap() *IoStats {
+	if x != nil {
+		return x.Unmap
+	}
+	return nil
+}
+
+func (x *VolumeStats) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *VolumeStats) GetTicks() int64 {
+	if x != nil {
+		return x.Ticks
+	}
+	return 0
+}
+
+// Counters and rates of one kind of operation
+type IoStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bytes      int64 `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Operations int64 `protobuf:"varint,2,opt,name=operations,proto3" json:"operations,omitempty"`
+	// total time the operations took
+	LatencyUs float64 `protobuf:"fixed64,3,opt,name=latency_us,json=latencyUs,proto3" json:"latency_us,omitempty"`
+	// operations and bytes per second over the interval
+	Iops      float64 `protobuf:"fixed64,4,opt,name=iops,proto3" json:"iops,omitempty"`
+	Bandwidth float64 `protobuf:"fixed64,5,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// bytes and operations within the interval
+	BytesDelta      int64 `protobuf:"varint,6,opt,name=bytes_delta,json=bytesDelta,proto3" json:"bytes_delta,omitempty"`
+	OperationsDelta int64 `protobuf:"varint,7,opt,name=operations_delta,json=operationsDelta,proto3" json:"operations_delta,omitempty"`
+}
+
+func (x *IoStats) Reset() {
+	*x = IoStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_volume_stats_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IoStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IoStats) ProtoMessage() {}
+
+func (x *IoStats) ProtoReflect() protoreflect.Message {
+	mi := &file_volume_stats_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IoStats.ProtoReflect.Descriptor instead.
+func (*IoStats) Descriptor() ([]byte, []int) {
+	return file_volume_stats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IoStats) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *IoStats) GetOperations() int64 {
+	if x != nil {
+		return x.Operations
+	}
+	return 0
+}
+
+func (x *IoStats) GetLatencyUs() float64 {
+	if x != nil {
+		return x.LatencyUs
+	}
+	return 0
+}
+
+func (x *IoStats) GetIops() float64 {
+	if x != nil {
+		return x.Iops
+	}
+	return 0
+}
+
+func (x *IoStats) GetBandwidth() float64 {
+	if x != nil {
+		return x.Bandwidth
+	}
+	return 0
+}
+
+func (x *IoStats) GetBytesDelta() int64 {
+	if x != nil {
+		return x.BytesDelta
+	}
+	return 0
+}
+
+func (x *IoStats) GetOperationsDelta() int64 {
+	if x != nil {
+		return x.OperationsDelta
+	}
+	return 0
+}
+
+var File_volume_stats_proto protoreflect.FileDescriptor
+
+var file_volume_stats_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x12, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62,
+	0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x10, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x5f, 0x6b, 0x65, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x82, 0x01, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x3b, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f,
+	0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x22,
+	0xac, 0x02, 0x0a, 0x0b, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12,
+	0x39, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79,
+	0x52, 0x08, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x2f, 0x0a, 0x04, 0x72, 0x65,
+	0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73,
+	0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6f,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x04, 0x72, 0x65, 0x61, 0x64, 0x12, 0x31, 0x0a, 0x05, 0x77,
+	0x72, 0x69, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6f, 0x70, 0x69,
+	0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x49, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x77, 0x72, 0x69, 0x74, 0x65, 0x12, 0x31,
+	0x0a, 0x05, 0x75, 0x6e, 0x6d, 0x61, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x49, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05, 0x75, 0x6e, 0x6d, 0x61,
+	0x70, 0x12, 0x35, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x63, 0x6b,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x69, 0x63, 0x6b, 0x73, 0x22, 0xdc,
+	0x01, 0x0a, 0x07, 0x49, 0x6f, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x75, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x55, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x69, 0x6f, 0x70, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x69,
+	0x6f, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74,
+	0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x79, 0x74, 0x65, 0x73, 0x44, 0x65, 0x6c,
+	0x74, 0x61, 0x12, 0x29, 0x0a, 0x10, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x5f, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x44, 0x65, 0x6c, 0x74, 0x61, 0x32, 0x74, 0x0a,
+	0x12, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x5e, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x29, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b,
+	0x5f, 0x62, 0x72, 0x69, 0x64, 0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6f,
+	0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x6f, 0x70, 0x69, 0x5f, 0x73, 0x70, 0x64, 0x6b, 0x5f, 0x62, 0x72, 0x69, 0x64,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x22, 0x00, 0x42, 0x1d, 0x5a, 0x1b, 0x6f, 0x70, 0x69, 0x2e, 0x73, 0x74, 0x6f, 0x72, 0x61,
+	0x67, 0x65, 0x2e, 0x76, 0x31, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x67, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_volume_stats_proto_rawDescOnce sync.Once
+	file_volume_stats_proto_rawDescData = file_volume_stats_proto_rawDesc
+)
+
+func file_volume_stats_proto_rawDescGZIP() []byte {
+	file_volume_stats_proto_rawDescOnce.Do(func() {
+		file_volume_stats_proto_rawDescData = protoimpl.X.CompressGZIP(file_volume_stats_proto_rawDescData)
+	})
+	return file_volume_stats_proto_rawDescData
+}
+
+var file_volume_stats_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_volume_stats_proto_goTypes = []interface{}{
+	(*GetVolumeStatsRequest)(nil), // 0: opi_spdk_bridge.v1.GetVolumeStatsRequest
+	(*VolumeStats)(nil),           // 1: opi_spdk_bridge.v1.VolumeStats
+	(*IoStats)(nil),               // 2: opi_spdk_bridge.v1.IoStats
+	(*_go.ObjectKey)(nil),         // 3: opi_api.common.v1.ObjectKey
+	(*durationpb.Duration)(nil),   // 4: google.protobuf.Duration
+}
+var file_volume_stats_proto_depIdxs = []int32{
+	3, // 0: opi_spdk_bridge.v1.GetVolumeStatsRequest.id:type_name -> opi_api.common.v1.ObjectKey
+	1, // 1: opi_spdk_bridge.v1.GetVolumeStatsRequest.previous:type_name -> opi_spdk_bridge.v1.VolumeStats
+	3, // 2: opi_spdk_bridge.v1.VolumeStats.volume_id:type_name -> opi_api.common.v1.ObjectKey
+	2, // 3: opi_spdk_bridge.v1.VolumeStats.read:type_name -> opi_spdk_bridge.v1.IoStats
+	2, // 4: opi_spdk_bridge.v1.VolumeStats.write:type_name -> opi_spdk_bridge.v1.IoStats
+	2, // 5: opi_spdk_bridge.v1.VolumeStats.unmap:type_name -> opi_spdk_bridge.v1.IoStats
+	4, // 6: opi_spdk_bridge.v1.VolumeStats.interval:type_name -> google.protobuf.Duration
+	0, // 7: opi_spdk_bridge.v1.VolumeStatsService.GetVolumeStats:input_type -> opi_spdk_bridge.v1.GetVolumeStatsRequest
+	1, // 8: opi_spdk_bridge.v1.VolumeStatsService.GetVolumeStats:output_type -> opi_spdk_bridge.v1.VolumeStats
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_volume_stats_proto_init() }
+func file_volume_stats_proto_init() {
+	if File_volume_stats_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_volume_stats_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetVolumeStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_volume_stats_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_volume_stats_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IoStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_volume_stats_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_volume_stats_proto_goTypes,
+		DependencyIndexes: file_volume_stats_proto_depIdxs,
+		MessageInfos:      file_volume_stats_proto_msgTypes,
+	}.Build()
+	File_volume_stats_proto = out.File
+	file_volume_stats_proto_rawDesc = nil
+	file_volume_stats_proto_goTypes = nil
+	file_volume_stats_proto_depIdxs = nil
+}
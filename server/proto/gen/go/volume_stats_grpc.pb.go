@@ -0,0 +1,109 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.4
+// source: volume_stats.proto
+
+package _go
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// VolumeStatsServiceClient is the client API for VolumeStatsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type VolumeStatsServiceClient interface {
+	// samples the volume, with the rates over the interval since the sample
+	// of the request
+	GetVolumeStats(ctx context.Context, in *GetVolumeStatsRequest, opts ...grpc.CallOption) (*VolumeStats, error)
+}
+
+type volumeStatsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVolumeStatsServiceClient(cc grpc.ClientConnInterface) VolumeStatsServiceClient {
+	return &volumeStatsServiceClient{cc}
+}
+
+func (c *volumeStatsServiceClient) GetVolumeStats(ctx context.Context, in *GetVolumeStatsRequest, opts ...grpc.CallOption) (*VolumeStats, error) {
+	out := new(VolumeStats)
+	err := c.cc.Invoke(ctx, "/opi_spdk_bridge.v1.VolumeStatsService/GetVolumeStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VolumeStatsServiceServer is the server API for VolumeStatsService service.
+// All implementations must embed UnimplementedVolumeStatsServiceServer
+// for forward compatibility
+type VolumeStatsServiceServer interface {
+	// samples the volume, with the rates over the interval since the sample
+	// of the request
+	GetVolumeStats(context.Context, *GetVolumeStatsRequest) (*VolumeStats, error)
+	mustEmbedUnimplementedVolumeStatsServiceServer()
+}
+
+// UnimplementedVolumeStatsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedVolumeStatsServiceServer struct {
+}
+
+func (UnimplementedVolumeStatsServiceServer) GetVolumeStats(context.Context, *GetVolumeStatsRequest) (*VolumeStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolumeStats not implemented")
+}
+func (UnimplementedVolumeStatsServiceServer) mustEmbedUnimplementedVolumeStatsServiceServer() {}
+
+// UnsafeVolumeStatsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VolumeStatsServiceServer will
+// result in compilation errors.
+type UnsafeVolumeStatsServiceServer interface {
+	mustEmbedUnimplementedVolumeStatsServiceServer()
+}
+
+func RegisterVolumeStatsServiceServer(s grpc.ServiceRegistrar, srv VolumeStatsServiceServer) {
+	s.RegisterService(&VolumeStatsService_ServiceDesc, srv)
+}
+
+func _VolumeStatsService_GetVolumeStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VolumeStatsServiceServer).GetVolumeStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/opi_spdk_bridge.v1.VolumeStatsService/GetVolumeStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VolumeStatsServiceServer).GetVolumeStats(ctx, req.(*GetVolumeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VolumeStatsService_ServiceDesc is the grpc.ServiceDesc for VolumeStatsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VolumeStatsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "opi_spdk_bridge.v1.VolumeStatsService",
+	HandlerType: (*VolumeStatsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetVolumeStats",
+			Handler:    _VolumeStatsService_GetVolumeStats_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "volume_stats.proto",
+}
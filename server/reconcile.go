@@ -0,0 +1,527 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+var (
+	reconcilePolicy   = flag.String("reconcile_policy", policyReport, "What to do with bridge objects missing from SPDK: report, or recreate them")
+	reconcileInterval = flag.Duration("reconcile_interval", 5*time.Minute, "How often to compare bridge objects with SPDK after the pass at startup, 0 to compare only at startup")
+)
+
+const (
+	policyReport   = "report"
+	policyRecreate = "recreate"
+)
+
+// reconcileItem is an object found on only one side of the comparison
+type reconcileItem struct {
+	Kind string `json:"kind"`
+	// ID is the bridge object ID, empty for orphans
+	ID string `json:"id,omitempty"`
+	// Name is how SPDK knows the object, for dangling objects the ID of
+	// the subsystem they belonged to
+	Name      string `json:"name"`
+	Recreated bool   `json:"recreated,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// reconcileReport is the outcome of one reconciliation pass. Missing
+// objects are known to the bridge but not to SPDK, orphans the other way
+// around. Dangling objects belong to a subsystem the bridge no longer
+// knows, they are never recreated.
+type reconcileReport struct {
+	Time     time.Time       `json:"time"`
+	Policy   string          `json:"policy"`
+	Missing  []reconcileItem `json:"missing"`
+	Orphans  []reconcileItem `json:"orphans"`
+	Dangling []reconcileItem `json:"dangling"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// recreateKey marks the context of a reconciler re-creating an object SPDK
+// lost, for the Create handlers not to answer it from the registry
+type recreateKey struct{}
+
+func recreating(ctx context.Context) bool {
+	v, _ := ctx.Value(recreateKey{}).(bool)
+	return v
+}
+
+// spdkState is the part of the live SPDK configuration the bridge manages
+type spdkState struct {
+	subsystems  map[string]map[int]bool        // NQN -> NSIDs
+	listeners   map[string][]NvmfListenAddress // NQN -> addresses
+	hosts       map[string]map[string]bool     // NQN -> host NQNs
+	anyHost     map[string]bool                // NQNs allowing any host
+	transports  map[string]bool                // upper case types
+	bdevs       map[string]bool
+	vhosts      map[string]bool // vhost-blk controllers
+	controllers map[string]bool // NVMe controllers
+}
+
+// reconciler compares the bridge objects with what SPDK actually has
+type reconciler struct {
+	s      *server
+	policy string
+
+	pass sync.Mutex // one pass at a time
+
+	mu   sync.Mutex
+	last *reconcileReport
+}
+
+func newReconciler(s *server, policy string) (*reconciler, error) {
+	if policy != policyReport && policy != policyRecreate {
+		return nil, fmt.Errorf("unknown policy %q, expecting %s or %s", policy, policyReport, policyRecreate)
+	}
+	return &reconciler{s: s, policy: policy}, nil
+}
+
+// run reconciles once and then every interval until ctx is done
+func (r *reconciler) run(ctx context.Context, interval time.Duration) {
+	r.reconcile(ctx)
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reconcile(ctx)
+		}
+	}
+}
+
+// lastReport returns the report of the latest pass, nil before the first
+func (r *reconciler) lastReport() *reconcileReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+func (r *reconciler) reconcile(ctx context.Context) *reconcileReport {
+	r.pass.Lock()
+	defer r.pass.Unlock()
+	report := &reconcileReport{
+		Time:     time.Now(),
+		Policy:   r.policy,
+		Missing:  []reconcileItem{},
+		Orphans:  []reconcileItem{},
+		Dangling: []reconcileItem{},
+	}
+	live, err := fetchSpdkState(ctx)
+	if err != nil {
+		log.Printf("reconcile: error: %v", err)
+		report.Error = err.Error()
+	} else {
+		r.diff(ctx, live, report)
+		log.Printf("reconcile: %d missing, %d orphans, %d dangling", len(report.Missing), len(report.Orphans), len(report.Dangling))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = report
+	return report
+}
+
+func fetchSpdkState(ctx context.Context) (*spdkState, error) {
+	live := &spdkState{
+		subsystems:  map[string]map[int]bool{},
+		listeners:   map[string][]NvmfListenAddress{},
+		hosts:       map[string]map[string]bool{},
+		anyHost:     map[string]bool{},
+		transports:  map[string]bool{},
+		bdevs:       map[string]bool{},
+		vhosts:      map[string]bool{},
+		controllers: map[string]bool{},
+	}
+	var subsystems []NvmfGetSubsystemsResult
+	if err := call(ctx, "nvmf_get_subsystems", nil, &subsystems); err != nil {
+		return nil, err
+	}
+	for i := range subsystems {
+		r := &subsystems[i]
+		if r.Subtype == "Discovery" {
+			continue
+		}
+		nsids := map[int]bool{}
+		for j := range r.Namespaces {
+			nsids[r.Namespaces[j].Nsid] = true
+		}
+		live.subsystems[r.Nqn] = nsids
+		live.listeners[r.Nqn] = r.ListenAddresses
+		hosts := map[string]bool{}
+		for j := range r.Hosts {
+			hosts[r.Hosts[j].Nqn] = true
+		}
+		live.hosts[r.Nqn] = hosts
+		live.anyHost[r.Nqn] = r.AllowAnyHost
+	}
+	var transports []NvmfGetTransportsResult
+	if err := call(ctx, "nvmf_get_transports", nil, &transports); err != nil {
+		return nil, err
+	}
+	for i := range transports {
+		live.transports[strings.ToUpper(transports[i].Trtype)] = true
+	}
+	var bdevs []BdevGetBdevsResult
+	if err := call(ctx, "bdev_get_bdevs", nil, &bdevs); err != nil {
+		return nil, err
+	}
+	for i := range bdevs {
+		live.bdevs[bdevs[i].Name] = true
+	}
+	var vhosts []VhostGetControllersResult
+	if err := call(ctx, "vhost_get_controllers", nil, &vhosts); err != nil {
+		return nil, err
+	}
+	for i := range vhosts {
+		// vhost-scsi controllers have no block backend
+		if vhosts[i].BackendSpecific.Block.Bdev != "" {
+			live.vhosts[vhosts[i].Ctrlr] = true
+		}
+	}
+	var controllers []BdevNvmeGetControllerResult
+	if err := call(ctx, "bdev_nvme_get_controllers", nil, &controllers); err != nil {
+		return nil, err
+	}
+	for i := range controllers {
+		live.controllers[controllers[i].Name] = true
+	}
+	return live, nil
+}
+
+// diff fills report, recreating missing objects if the policy says so.
+// Objects are visited in dependency order so that a recreated bdev exists
+// before the namespace or vhost controller on top of it is recreated.
+func (r *reconciler) diff(ctx context.Context, live *spdkState, report *reconcileReport) {
+	s := r.s
+	ctx = context.WithValue(ctx, recreateKey{}, true)
+	// bdevs the bridge created or put something on top of
+	used := map[string]bool{}
+	// bdev name prefixes of the managed NVMe controllers
+	var prefixes []string
+
+	for _, m := range s.registry.nullDebugs.values() {
+		device := m.(*pb.NullDebug)
+		name := device.Handle.Value
+		used[name] = true
+		if !live.bdevs[name] {
+			r.missing(report, "null_debug", name, name, func() error {
+				_, err := s.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: device})
+				return err
+			})
+		}
+	}
+	for _, m := range s.registry.aioControllers.values() {
+		device := m.(*pb.AioController)
+		name := device.Handle.Value
+		used[name] = true
+		if !live.bdevs[name] {
+			r.missing(report, "aio_controller", name, name, func() error {
+				_, err := s.AioControllerCreate(ctx, &pb.AioControllerCreateRequest{Device: device})
+				return err
+			})
+		}
+	}
+	desiredControllers := map[string]bool{}
+	for _, m := range s.registry.remoteControllers.values() {
+		ctrl := m.(*pb.NVMfRemoteController)
+		name := fmt.Sprint("OpiNvme", ctrl.Id)
+		desiredControllers[name] = true
+		prefixes = append(prefixes, name+"n")
+		if !live.controllers[name] {
+			r.missing(report, "nvmf_remote_controller", fmt.Sprint(ctrl.Id), name, func() error {
+				_, err := s.NVMfRemoteControllerConnect(ctx, &pb.NVMfRemoteControllerConnectRequest{Ctrl: ctrl})
+				return err
+			})
+		}
+	}
+	for _, m := range s.registry.cryptos.values() {
+		crypto := m.(*pb.Crypto)
+		name := crypto.CryptoId.Value
+		used[name] = true
+		used[crypto.GetVolumeId().GetValue()] = true
+		if !live.bdevs[name] {
+			r.missing(report, "crypto", name, name, func() error {
+				if len(crypto.Key) == 0 {
+					return fmt.Errorf("the key of crypto %s is not kept across restarts, create it again", name)
+				}
+				_, err := s.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: crypto})
+				return err
+			})
+		}
+	}
+
+	for _, m := range s.registry.transports.values() {
+		transport := m.(*bridge.NVMfTransport)
+		name := spdkTrtypes[transport.Spec.Trtype]
+		if !live.transports[name] {
+			r.missing(report, "nvmf_transport", transport.Spec.Id.Value, name, func() error {
+				_, err := s.CreateNVMfTransport(ctx, &bridge.CreateNVMfTransportRequest{Transport: transport})
+				return err
+			})
+		}
+	}
+
+	desiredSubsystems := map[string]map[int]bool{}
+	for _, m := range s.registry.subsystems.values() {
+		subsys := m.(*pb.NVMeSubsystem)
+		nqn := subsys.Spec.Nqn
+		desiredSubsystems[nqn] = map[int]bool{}
+		if _, ok := live.subsystems[nqn]; !ok {
+			r.missing(report, "nvme_subsystem", subsys.Spec.Id.Value, nqn, func() error {
+				_, err := s.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: subsys})
+				return err
+			})
+		}
+	}
+	for _, m := range s.registry.namespaces.values() {
+		namespace := m.(*pb.NVMeNamespace)
+		subsys, ok := s.registry.subsystem(namespace.Spec.SubsystemId.GetValue())
+		if !ok {
+			r.dangling(report, "nvme_namespace", namespace.Spec.Id.Value, namespace.Spec.SubsystemId.GetValue())
+			continue
+		}
+		nqn := subsys.Spec.Nqn
+		nsid := int(namespace.Spec.HostNsid)
+		used[namespace.Spec.GetVolumeId().GetValue()] = true
+		if nsids, ok := desiredSubsystems[nqn]; ok {
+			nsids[nsid] = true
+		}
+		if !live.subsystems[nqn][nsid] {
+			r.missing(report, "nvme_namespace", namespace.Spec.Id.Value, fmt.Sprintf("%s/%d", nqn, nsid), func() error {
+				_, err := s.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: namespace})
+				return err
+			})
+		}
+	}
+
+	for _, m := range s.registry.listeners.values() {
+		listener := m.(*bridge.NVMfListener)
+		subsys, ok := s.registry.subsystem(listener.Spec.SubsystemId.GetValue())
+		if !ok {
+			r.dangling(report, "nvmf_listener", listener.Spec.Id.Value, listener.Spec.SubsystemId.GetValue())
+			continue
+		}
+		addr, err := listenAddress(listener.Spec)
+		if err != nil {
+			continue
+		}
+		if !hasListenAddress(live.listeners[subsys.Spec.Nqn], addr) {
+			name := fmt.Sprintf("%s/%s:%s:%s", subsys.Spec.Nqn, addr.Trtype, addr.Traddr, addr.Trsvcid)
+			r.missing(report, "nvmf_listener", listener.Spec.Id.Value, name, func() error {
+				_, err := s.CreateNVMfListener(ctx, &bridge.CreateNVMfListenerRequest{Listener: listener})
+				return err
+			})
+		}
+	}
+
+	for _, m := range s.registry.controllers.values() {
+		controller := m.(*pb.NVMeController)
+		subsys, ok := s.registry.subsystem(controller.Spec.SubsystemId.GetValue())
+		if !ok {
+			r.dangling(report, "nvme_controller", controller.Spec.Id.Value, controller.Spec.SubsystemId.GetValue())
+			continue
+		}
+		addr, err := controllerAddress(controller.Spec)
+		if err != nil {
+			continue
+		}
+		if !hasListenAddress(live.listeners[subsys.Spec.Nqn], addr) {
+			name := fmt.Sprintf("%s/%s:%s:%s", subsys.Spec.Nqn, addr.Trtype, addr.Traddr, addr.Trsvcid)
+			r.missing(report, "nvme_controller", controller.Spec.Id.Value, name, func() error {
+				_, err := s.CreateNVMeController(ctx, &pb.CreateNVMeControllerRequest{Controller: controller})
+				return err
+			})
+		}
+	}
+
+	// a subsystem missing altogether is recreated with its access already
+	for _, m := range s.registry.accesses.values() {
+		access := m.(*bridge.NVMfSubsystemAccess)
+		subsys, ok := s.registry.subsystem(access.SubsystemId.Value)
+		if !ok {
+			continue
+		}
+		nqn := subsys.Spec.Nqn
+		if _, ok := live.subsystems[nqn]; ok && live.anyHost[nqn] != access.AllowAnyHost {
+			name := fmt.Sprintf("%s/allow_any_host=%v", nqn, access.AllowAnyHost)
+			r.missing(report, "nvmf_subsystem_access", access.SubsystemId.Value, name, func() error {
+				_, err := s.UpdateNVMfSubsystemAccess(ctx, &bridge.UpdateNVMfSubsystemAccessRequest{Access: access})
+				return err
+			})
+		}
+	}
+	for _, m := range s.registry.hosts.values() {
+		host := m.(*bridge.NVMfHost)
+		subsys, ok := s.registry.subsystem(host.Spec.SubsystemId.GetValue())
+		if !ok {
+			r.dangling(report, "nvmf_host", host.Spec.Id.Value, host.Spec.SubsystemId.GetValue())
+			continue
+		}
+		if !live.hosts[subsys.Spec.Nqn][host.Spec.HostNqn] {
+			name := fmt.Sprintf("%s/%s", subsys.Spec.Nqn, host.Spec.HostNqn)
+			r.missing(report, "nvmf_host", host.Spec.Id.Value, name, func() error {
+				_, err := s.CreateNVMfHost(ctx, &bridge.CreateNVMfHostRequest{Host: host})
+				return err
+			})
+		}
+	}
+
+	desiredVhosts := map[string]bool{}
+	for _, m := range s.registry.virtioBlks.values() {
+		blk := m.(*pb.VirtioBlk)
+		name := blk.Id.Value
+		desiredVhosts[name] = true
+		used[blk.GetVolumeId().GetValue()] = true
+		if !live.vhosts[name] {
+			r.missing(report, "virtio_blk", name, name, func() error {
+				_, err := s.CreateVirtioBlk(ctx, &pb.CreateVirtioBlkRequest{Controller: blk})
+				return err
+			})
+		}
+	}
+
+	nqns := make([]string, 0, len(live.subsystems))
+	for nqn := range live.subsystems {
+		nqns = append(nqns, nqn)
+	}
+	sort.Strings(nqns)
+	for _, nqn := range nqns {
+		nsids, ok := desiredSubsystems[nqn]
+		if !ok {
+			r.orphan(report, "nvme_subsystem", nqn)
+			continue
+		}
+		for _, nsid := range sortedNsids(live.subsystems[nqn]) {
+			if !nsids[nsid] {
+				r.orphan(report, "nvme_namespace", fmt.Sprintf("%s/%d", nqn, nsid))
+			}
+		}
+	}
+	for _, name := range sortedNames(live.bdevs) {
+		if !used[name] && !hasAnyPrefix(name, prefixes) {
+			r.orphan(report, "bdev", name)
+		}
+	}
+	for _, name := range sortedNames(live.vhosts) {
+		if !desiredVhosts[name] {
+			r.orphan(report, "virtio_blk", name)
+		}
+	}
+	for _, name := range sortedNames(live.controllers) {
+		if !desiredControllers[name] {
+			r.orphan(report, "nvmf_remote_controller", name)
+		}
+	}
+}
+
+func (r *reconciler) missing(report *reconcileReport, kind, id, name string, recreate func() error) {
+	item := reconcileItem{Kind: kind, ID: id, Name: name}
+	if r.policy == policyRecreate {
+		err := recreate()
+		switch {
+		case errors.Is(err, errRemoved):
+			log.Printf("reconcile: %s %s (%s) was deleted meanwhile", kind, id, name)
+			return
+		case err != nil:
+			item.Error = err.Error()
+			log.Printf("reconcile: could not recreate %s %s (%s): %v", kind, id, name, err)
+		default:
+			item.Recreated = true
+			log.Printf("reconcile: recreated %s %s (%s)", kind, id, name)
+		}
+	} else {
+		log.Printf("reconcile: %s %s (%s) is missing from SPDK", kind, id, name)
+	}
+	report.Missing = append(report.Missing, item)
+}
+
+func (r *reconciler) dangling(report *reconcileReport, kind, id, subsystemID string) {
+	log.Printf("reconcile: %s %s belongs to unknown subsystem %s", kind, id, subsystemID)
+	report.Dangling = append(report.Dangling, reconcileItem{Kind: kind, ID: id, Name: subsystemID})
+}
+
+func (r *reconciler) orphan(report *reconcileReport, kind, name string) {
+	log.Printf("reconcile: %s %s is not managed by the bridge", kind, name)
+	report.Orphans = append(report.Orphans, reconcileItem{Kind: kind, Name: name})
+}
+
+// ServeHTTP returns the latest report on GET and runs a pass on POST
+func (r *reconciler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var report *reconcileReport
+	switch req.Method {
+	case http.MethodGet:
+		report = r.lastReport()
+		if report == nil {
+			http.Error(w, "no reconciliation has run yet", http.StatusNotFound)
+			return
+		}
+	case http.MethodPost:
+		report = r.reconcile(req.Context())
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("error: %v", err)
+	}
+}
+
+func sortedNames(m map[string]bool) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func hasListenAddress(addrs []NvmfListenAddress, addr NvmfListenAddress) bool {
+	for _, a := range addrs {
+		if sameListenAddress(a, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedNsids(m map[int]bool) []int {
+	nsids := make([]int, 0, len(m))
+	for nsid := range m {
+		nsids = append(nsids, nsid)
+	}
+	sort.Ints(nsids)
+	return nsids
+}
@@ -0,0 +1,401 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// createEverything creates one bridge object of each kind the reconciler
+// checks
+func createEverything(t *testing.T, c *bridgeClients) {
+	ctx := context.Background()
+	createTestNamespace(t, c)
+	createTestTransport(t, c)
+	createTestListener(t, c)
+	createTestController(t, c)
+	createTestHost(t, c)
+	restrictTestSubsystem(t, c)
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.remote.NVMfRemoteControllerConnect(ctx, &pb.NVMfRemoteControllerConnectRequest{Ctrl: testRemoteController()}); err != nil {
+		t.Fatal(err)
+	}
+	blk := &pb.VirtioBlk{Id: &pc.ObjectKey{Value: "virtio-blk-42"}, VolumeId: &pc.ObjectKey{Value: "Null42"}}
+	if _, err := c.virtioBlk.CreateVirtioBlk(ctx, &pb.CreateVirtioBlkRequest{Controller: blk}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func reportKinds(items []reconcileItem) map[string]string {
+	kinds := map[string]string{}
+	for _, item := range items {
+		kinds[item.Name] = item.Kind
+	}
+	return kinds
+}
+
+func TestReconcile_InSync(t *testing.T) {
+	_, c := startBridge(t)
+	createEverything(t, c)
+	r, err := newReconciler(c.server, policyReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := r.reconcile(context.Background())
+	if report.Error != "" || len(report.Missing) != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	// Malloc0 backs the crypto bdev and Malloc1 the namespace
+	if len(report.Orphans) != 0 {
+		t.Errorf("unexpected orphans %+v", report.Orphans)
+	}
+}
+
+func TestReconcile_SpdkRestarted(t *testing.T) {
+	tests := map[string]struct {
+		policy    string
+		recreated bool
+	}{
+		"report":   {policyReport, false},
+		"recreate": {policyRecreate, true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, c := startBridge(t)
+			createEverything(t, c)
+			// SPDK comes back empty
+			newFakeSpdk(t)
+			r, err := newReconciler(c.server, tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			report := r.reconcile(context.Background())
+			want := map[string]string{
+				"Null42":                        "null_debug",
+				"OpiNvme8":                      "nvmf_remote_controller",
+				"Crypto42":                      "crypto",
+				testNqn:                         "nvme_subsystem",
+				testNqn + "/1":                  "nvme_namespace",
+				testNqn + "/TCP:127.0.0.1:4420": "nvmf_listener",
+				"TCP":                           "nvmf_transport",
+				testNqn + "/VFIOUSER:" + filepath.Join(*vfiouserDir, "port0-pf1-vf0") + ":0": "nvme_controller",
+				testNqn + "/" + testHostNqn: "nvmf_host",
+				"virtio-blk-42":             "virtio_blk",
+			}
+			if got := reportKinds(report.Missing); !reflect.DeepEqual(got, want) {
+				t.Errorf("expected missing %v, got %v", want, got)
+			}
+			for _, item := range report.Missing {
+				if item.Recreated != tt.recreated || item.Error != "" {
+					t.Errorf("unexpected item %+v", item)
+				}
+			}
+
+			report = r.reconcile(context.Background())
+			if tt.recreated && len(report.Missing) != 0 {
+				t.Errorf("expected all objects recreated, still missing %+v", report.Missing)
+			}
+			if !tt.recreated && len(report.Missing) != len(want) {
+				t.Errorf("expected objects left alone, missing %+v", report.Missing)
+			}
+		})
+	}
+}
+
+func TestReconcile_Orphans(t *testing.T) {
+	_, c := startBridge(t)
+	createTestNamespace(t, c)
+	ctx := context.Background()
+	// created behind the bridge's back
+	if err := call(ctx, "nvmf_create_subsystem", &NvmfCreateSubsystemParams{Nqn: "nqn.2022-09.io.spdk:manual"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	params := NvmfSubsystemAddNsParams{Nqn: testNqn}
+	params.Namespace.BdevName = "Malloc0"
+	if err := call(ctx, "nvmf_subsystem_add_ns", &params, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := call(ctx, "bdev_null_create", &BdevNullCreateParams{Name: "Null7", BlockSize: 512, NumBlocks: 64}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := r.reconcile(ctx)
+	want := map[string]string{
+		"nqn.2022-09.io.spdk:manual": "nvme_subsystem",
+		testNqn + "/2":               "nvme_namespace",
+		"Null7":                      "bdev",
+		// only used by the unmanaged namespace
+		"Malloc0": "bdev",
+	}
+	if got := reportKinds(report.Orphans); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected orphans %v, got %v", want, got)
+	}
+	if len(report.Missing) != 0 {
+		t.Errorf("unexpected missing %+v", report.Missing)
+	}
+}
+
+// a subsystem opened to any host behind the bridge's back is restricted again
+func TestReconcile_AccessChanged(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	restrictTestSubsystem(t, c)
+	ctx := context.Background()
+	if err := call(ctx, "nvmf_subsystem_allow_any_host", &NvmfSubsystemAllowAnyHostParams{Nqn: testNqn, AllowAnyHost: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := r.reconcile(ctx)
+	want := map[string]string{testNqn + "/allow_any_host=false": "nvmf_subsystem_access"}
+	if got := reportKinds(report.Missing); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected missing %v, got %v", want, got)
+	}
+	spdk.mu.Lock()
+	allowAnyHost := spdk.subsystems[testNqn].allowAnyHost
+	spdk.mu.Unlock()
+	if allowAnyHost {
+		t.Error("expected the subsystem restricted again")
+	}
+}
+
+func TestReconcile_SpdkDown(t *testing.T) {
+	spdk, c := startBridge(t)
+	spdk.setError("bdev_get_bdevs", -32603, "internal")
+	r, err := newReconciler(c.server, policyReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := r.reconcile(context.Background())
+	if report.Error == "" {
+		t.Errorf("expected the failure in the report, got %+v", report)
+	}
+}
+
+func TestReconcile_Policy(t *testing.T) {
+	if _, err := newReconciler(&server{}, "delete"); err == nil {
+		t.Error("expected an unknown policy to be refused")
+	}
+}
+
+func TestReconcile_HTTP(t *testing.T) {
+	_, c := startBridge(t)
+	r, err := newReconciler(c.server, policyReport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected no report before the first pass, got %s", resp.Status)
+	}
+
+	for _, method := range []string{http.MethodPost, http.MethodGet} {
+		req, err := http.NewRequestWithContext(context.Background(), method, ts.URL, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var report reconcileReport
+		err = json.NewDecoder(resp.Body).Decode(&report)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != http.StatusOK || report.Policy != policyReport || report.Time.IsZero() {
+			t.Errorf("%s: unexpected response %s %+v", method, resp.Status, report)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, ts.URL, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("expected DELETE refused, got %s", resp.Status)
+	}
+}
+
+// recreating goes through the regular handlers, so SPDK errors surface
+// per item
+func TestReconcile_RecreateFails(t *testing.T) {
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	spdk := newFakeSpdk(t)
+	spdk.setError("nvmf_create_subsystem", fakeInvalidParams, "failed")
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := r.reconcile(context.Background())
+	if len(report.Missing) != 1 || report.Missing[0].Recreated || report.Missing[0].Error == "" {
+		t.Errorf("unexpected missing %+v", report.Missing)
+	}
+	// the bridge still knows the subsystem for the next pass
+	_, err = c.nvme.GetNVMeSubsystem(context.Background(), &pb.GetNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected the subsystem to be missing from SPDK only, got %v", err)
+	}
+}
+
+// crypto keys are not written to the store, so a restarted bridge cannot
+// recreate a crypto bdev SPDK lost
+func TestReconcile_CryptoWithoutKey(t *testing.T) {
+	newFakeSpdk(t)
+	path := filepath.Join(t.TempDir(), "bridge.db")
+	c, stop := openBridge(t, path)
+	if _, err := c.middleend.CreateCrypto(context.Background(), &pb.CreateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Fatal(err)
+	}
+	stop()
+	spdk := newFakeSpdk(t)
+	c, _ = openBridge(t, path)
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := r.reconcile(context.Background())
+	if len(report.Missing) != 1 || report.Missing[0].Recreated || report.Missing[0].Error == "" {
+		t.Errorf("unexpected missing %+v", report.Missing)
+	}
+	if n := spdk.called("bdev_crypto_create"); n != 0 {
+		t.Errorf("expected no crypto created without its key, got %d calls", n)
+	}
+}
+
+// a pass that finds an object missing while a client deletes it must not
+// bring it back
+func TestReconcile_DeleteConcurrently(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}}); err != nil {
+		t.Fatal(err)
+	}
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// SPDK deleted the bdev, the bridge did not forget it yet
+	release := spdk.holdResponses("bdev_null_delete")
+	deleted := make(chan error, 1)
+	go func() {
+		_, err := c.null.NullDebugDelete(ctx, &pb.NullDebugDeleteRequest{Handle: &pc.ObjectKey{Value: "Null42"}})
+		deleted <- err
+	}()
+	waitCalled(t, spdk, "bdev_null_delete", 1)
+	reconciled := make(chan *reconcileReport, 1)
+	go func() { reconciled <- r.reconcile(ctx) }()
+	// the pass finds the bdev missing and waits for the delete to recreate it
+	waitCalled(t, spdk, "bdev_nvme_get_controllers", 1)
+	time.Sleep(50 * time.Millisecond)
+	release()
+
+	if err := <-deleted; err != nil {
+		t.Fatal(err)
+	}
+	report := <-reconciled
+	if len(report.Missing) != 0 {
+		t.Errorf("expected the deleted bdev skipped, got %+v", report.Missing)
+	}
+	if n := spdk.called("bdev_null_create"); n != 1 {
+		t.Errorf("expected the deleted bdev not recreated, got %d creates", n)
+	}
+	if _, ok := c.server.registry.nullDebugs.load("Null42"); ok {
+		t.Error("expected the deleted bdev forgotten")
+	}
+}
+
+// waitCalled waits until method was called n times
+func waitCalled(t *testing.T, spdk *fakeSpdk, method string, n int) {
+	t.Helper()
+	for i := 0; spdk.called(method) < n; i++ {
+		if i == 500 {
+			t.Fatalf("%s not called", method)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// objects of a subsystem the bridge forgot, as an older bridge left them
+// behind, are reported and never recreated
+func TestReconcile_Dangling(t *testing.T) {
+	spdk, c := startBridge(t)
+	createEverything(t, c)
+	ctx := context.Background()
+	if err := c.server.registry.subsystems.remove(ctx, "subsystem-test", nil); err != nil {
+		t.Fatal(err)
+	}
+	spdk.mu.Lock()
+	delete(spdk.subsystems, testNqn)
+	spdk.mu.Unlock()
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := r.reconcile(ctx)
+	dangling := map[string]string{}
+	for _, item := range report.Dangling {
+		if item.Name != "subsystem-test" {
+			t.Errorf("unexpected subsystem of %+v", item)
+		}
+		dangling[item.ID] = item.Kind
+	}
+	want := map[string]string{
+		"namespace-test":  "nvme_namespace",
+		"listener-test":   "nvmf_listener",
+		"controller-test": "nvme_controller",
+		"host-test":       "nvmf_host",
+	}
+	if !reflect.DeepEqual(dangling, want) {
+		t.Errorf("expected dangling %v, got %v", want, dangling)
+	}
+	if len(report.Missing) != 0 {
+		t.Errorf("unexpected missing %+v", report.Missing)
+	}
+	if n := spdk.called("nvmf_subsystem_add_ns"); n != 1 {
+		t.Errorf("expected the namespace not recreated, got %d adds", n)
+	}
+}
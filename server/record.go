@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"log"
+	"sync"
+)
+
+var rpcRecord = flag.String("rpc_record", "", "File to append every SPDK JSON RPC request/response pair to, e.g. to capture golden files for tests")
+
+// rpcExchange is one request/response pair as stored in a golden file
+type rpcExchange struct {
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response"`
+}
+
+// recordingTransport passes requests on to another transport and writes
+// every answered request with its response to out. Transport failures are
+// not recorded since SPDK never saw or never answered those requests.
+type recordingTransport struct {
+	next rpcTransport
+
+	mu  sync.Mutex // serializes writes to out
+	out io.Writer
+}
+
+func newRecordingTransport(next rpcTransport, out io.Writer) *recordingTransport {
+	return &recordingTransport{next: next, out: out}
+}
+
+func (t *recordingTransport) exchange(ctx context.Context, id int32, data []byte) (*rpcResponse, error) {
+	response, err := t.next.exchange(ctx, id, data)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	encoder := json.NewEncoder(t.out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(&rpcExchange{Request: data, Response: raw}); err != nil {
+		// recording is best effort and must not fail the call
+		log.Printf("error recording SPDK exchange: %v", err)
+	}
+	return response, nil
+}
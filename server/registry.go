@@ -0,0 +1,388 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// errRemoved is returned to a reconciler re-creating an object that a
+// client deleted while the reconciler waited for it
+var errRemoved = errors.New("deleted meanwhile")
+
+// objectTable is a set of bridge objects of one kind keyed by their ID,
+// backed by an objectStore. Objects are copied on the way in and out, so
+// callers never share a message with a concurrent handler.
+type objectTable struct {
+	kind      string
+	newObject func() proto.Message
+	db        objectStore
+	// redact clears what must not be written to db, like key material. The
+	// table holds on to it only until the bridge restarts.
+	redact func(proto.Message)
+
+	mu      sync.RWMutex
+	objects map[string]proto.Message
+	// IDs an object is being saved or removed under, closed when done
+	busy map[string]chan struct{}
+}
+
+func newObjectTable(db objectStore, kind string, newObject func() proto.Message) (*objectTable, error) {
+	t := &objectTable{kind: kind, newObject: newObject, db: db, objects: map[string]proto.Message{}, busy: map[string]chan struct{}{}}
+	err := db.load(kind, func(id string, data []byte) error {
+		m := newObject()
+		if err := proto.Unmarshal(data, m); err != nil {
+			return fmt.Errorf("%s %s: %w", kind, id, err)
+		}
+		t.objects[id] = m
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// load returns a copy of the object stored under id
+func (t *objectTable) load(id string) (proto.Message, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	m, ok := t.objects[id]
+	if !ok {
+		return nil, false
+	}
+	return proto.Clone(m), true
+}
+
+// put runs apply, usually the SPDK calls creating the object, and then
+// saves a copy of m under id. apply may fill in what SPDK assigned into m
+// before it is saved. Nothing is saved when apply fails, and apply's error
+// is returned as is. apply runs outside of any store transaction, with
+// only id reserved, so calls for other objects go on meanwhile.
+func (t *objectTable) put(ctx context.Context, id string, m proto.Message, apply func() error) error {
+	_, err := t.save(ctx, id, m, true, apply)
+	return err
+}
+
+// create is put for an object that should not exist yet. When id is taken
+// by an object equal to m as it was before apply, that object is returned
+// without running apply; when by a different one, the error is
+// AlreadyExists. With replace set, create is put.
+func (t *objectTable) create(ctx context.Context, id string, m proto.Message, replace bool, apply func() error) (proto.Message, error) {
+	return t.save(ctx, id, m, replace, apply)
+}
+
+func (t *objectTable) save(ctx context.Context, id string, m proto.Message, replace bool, apply func() error) (proto.Message, error) {
+	release, err := t.reserve(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if recreating(ctx) {
+		if _, ok := t.load(id); !ok {
+			return nil, errRemoved
+		}
+	}
+	if !replace {
+		if old, ok := t.load(id); ok {
+			if !t.same(old, m) {
+				return nil, status.Errorf(codes.AlreadyExists, "%s %s already exists with a different spec", t.kind, id)
+			}
+			return old, nil
+		}
+	}
+	if apply != nil {
+		if err := apply(); err != nil {
+			return nil, err
+		}
+	}
+	data, err := proto.Marshal(t.stored(m))
+	if err != nil {
+		return nil, err
+	}
+	err = t.db.update(func(tx storeTx) error {
+		return tx.put(t.kind, id, data)
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.set(id, proto.Clone(m))
+	return nil, nil
+}
+
+// stored returns m as it is written to the store
+func (t *objectTable) stored(m proto.Message) proto.Message {
+	if t.redact == nil {
+		return m
+	}
+	m = proto.Clone(m)
+	t.redact(m)
+	return m
+}
+
+// same reports whether m is old, also when old was loaded from the store
+// without what redact clears
+func (t *objectTable) same(old, m proto.Message) bool {
+	if proto.Equal(old, m) {
+		return true
+	}
+	return t.redact != nil && proto.Equal(old, t.stored(old)) && proto.Equal(old, t.stored(m))
+}
+
+// remove runs apply, usually the SPDK calls deleting the object, and then
+// forgets the object stored under id. Like with put, only id is reserved
+// while apply runs.
+func (t *objectTable) remove(ctx context.Context, id string, apply func() error) error {
+	release, err := t.reserve(ctx, id)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if apply != nil {
+		if err := apply(); err != nil {
+			return err
+		}
+	}
+	err = t.db.update(func(tx storeTx) error {
+		return tx.remove(t.kind, id)
+	})
+	if err != nil {
+		return err
+	}
+	t.set(id, nil)
+	return nil
+}
+
+// reserve waits until no other call saves or removes an object under id,
+// or until ctx is done, and keeps id to the caller until release is called
+func (t *objectTable) reserve(ctx context.Context, id string) (release func(), err error) {
+	for {
+		t.mu.Lock()
+		busy, ok := t.busy[id]
+		if !ok {
+			done := make(chan struct{})
+			t.busy[id] = done
+			t.mu.Unlock()
+			return func() {
+				t.mu.Lock()
+				delete(t.busy, id)
+				t.mu.Unlock()
+				close(done)
+			}, nil
+		}
+		t.mu.Unlock()
+		select {
+		case <-busy:
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+// set stores m under id, or deletes id for a nil m
+func (t *objectTable) set(id string, m proto.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if m == nil {
+		delete(t.objects, id)
+	} else {
+		t.objects[id] = m
+	}
+}
+
+// values returns copies of all objects ordered by ID
+func (t *objectTable) values() []proto.Message {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	ids := make([]string, 0, len(t.objects))
+	for id := range t.objects {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	values := make([]proto.Message, len(ids))
+	for i, id := range ids {
+		values[i] = proto.Clone(t.objects[id])
+	}
+	return values
+}
+
+// registry holds every object created through the bridge, keyed by the
+// object ID the client chose
+type registry struct {
+	subsystems        *objectTable // *pb.NVMeSubsystem
+	controllers       *objectTable // *pb.NVMeController
+	namespaces        *objectTable // *pb.NVMeNamespace
+	virtioBlks        *objectTable // *pb.VirtioBlk
+	cryptos           *objectTable // *pb.Crypto
+	nullDebugs        *objectTable // *pb.NullDebug
+	aioControllers    *objectTable // *pb.AioController
+	remoteControllers *objectTable // *pb.NVMfRemoteController
+	listeners         *objectTable // *bridge.NVMfListener
+	hosts             *objectTable // *bridge.NVMfHost
+	transports        *objectTable // *bridge.NVMfTransport
+	// keyed by subsystem ID, without host NQNs
+	accesses *objectTable // *bridge.NVMfSubsystemAccess
+	// keyed by subsystem ID, without the subsystem
+	cntlidRanges *objectTable // *bridge.NVMfSubsystem
+	// keyed by namespace ID, once it differs from what the namespace was
+	// created with
+	visibilities *objectTable // *bridge.NVMeNamespaceVisibility
+}
+
+// newRegistry loads the objects saved in db
+func newRegistry(db objectStore) (*registry, error) {
+	r := &registry{}
+	tables := []struct {
+		table     **objectTable
+		kind      string
+		newObject func() proto.Message
+	}{
+		{&r.subsystems, "subsystems", func() proto.Message { return &pb.NVMeSubsystem{} }},
+		{&r.controllers, "controllers", func() proto.Message { return &pb.NVMeController{} }},
+		{&r.namespaces, "namespaces", func() proto.Message { return &pb.NVMeNamespace{} }},
+		{&r.virtioBlks, "virtio_blks", func() proto.Message { return &pb.VirtioBlk{} }},
+		{&r.cryptos, "cryptos", func() proto.Message { return &pb.Crypto{} }},
+		{&r.nullDebugs, "null_debugs", func() proto.Message { return &pb.NullDebug{} }},
+		{&r.aioControllers, "aio_controllers", func() proto.Message { return &pb.AioController{} }},
+		{&r.remoteControllers, "remote_controllers", func() proto.Message { return &pb.NVMfRemoteController{} }},
+		{&r.listeners, "listeners", func() proto.Message { return &bridge.NVMfListener{} }},
+		{&r.hosts, "hosts", func() proto.Message { return &bridge.NVMfHost{} }},
+		{&r.transports, "transports", func() proto.Message { return &bridge.NVMfTransport{} }},
+		{&r.accesses, "subsystem_accesses", func() proto.Message { return &bridge.NVMfSubsystemAccess{} }},
+		{&r.cntlidRanges, "subsystem_cntlid_ranges", func() proto.Message { return &bridge.NVMfSubsystem{} }},
+		{&r.visibilities, "namespace_visibilities", func() proto.Message { return &bridge.NVMeNamespaceVisibility{} }},
+	}
+	for _, t := range tables {
+		table, err := newObjectTable(db, t.kind, t.newObject)
+		if err != nil {
+			return nil, err
+		}
+		*t.table = table
+	}
+	r.cryptos.redact = func(m proto.Message) { m.(*pb.Crypto).Key = nil }
+	return r, nil
+}
+
+func (r *registry) subsystem(id string) (*pb.NVMeSubsystem, bool) {
+	m, ok := r.subsystems.load(id)
+	if !ok {
+		return nil, false
+	}
+	return m.(*pb.NVMeSubsystem), true
+}
+
+func (r *registry) controller(id string) (*pb.NVMeController, bool) {
+	m, ok := r.controllers.load(id)
+	if !ok {
+		return nil, false
+	}
+	return m.(*pb.NVMeController), true
+}
+
+func (r *registry) namespace(id string) (*pb.NVMeNamespace, bool) {
+	m, ok := r.namespaces.load(id)
+	if !ok {
+		return nil, false
+	}
+	return m.(*pb.NVMeNamespace), true
+}
+
+func (r *registry) listener(id string) (*bridge.NVMfListener, bool) {
+	m, ok := r.listeners.load(id)
+	if !ok {
+		return nil, false
+	}
+	return m.(*bridge.NVMfListener), true
+}
+
+func (r *registry) host(id string) (*bridge.NVMfHost, bool) {
+	m, ok := r.hosts.load(id)
+	if !ok {
+		return nil, false
+	}
+	return m.(*bridge.NVMfHost), true
+}
+
+func (r *registry) transport(id string) (*bridge.NVMfTransport, bool) {
+	m, ok := r.transports.load(id)
+	if !ok {
+		return nil, false
+	}
+	return m.(*bridge.NVMfTransport), true
+}
+
+// subsystemChildren lists the namespaces, controllers, listeners and hosts
+// of the subsystem, which go away with it in SPDK
+func (r *registry) subsystemChildren(subsystemID string) []string {
+	var children []string
+	for _, m := range r.namespaces.values() {
+		if m.(*pb.NVMeNamespace).Spec.SubsystemId.GetValue() == subsystemID {
+			children = append(children, "namespace "+m.(*pb.NVMeNamespace).Spec.Id.Value)
+		}
+	}
+	for _, m := range r.controllers.values() {
+		if m.(*pb.NVMeController).Spec.SubsystemId.GetValue() == subsystemID {
+			children = append(children, "controller "+m.(*pb.NVMeController).Spec.Id.Value)
+		}
+	}
+	for _, m := range r.listeners.values() {
+		if m.(*bridge.NVMfListener).Spec.SubsystemId.GetValue() == subsystemID {
+			children = append(children, "listener "+m.(*bridge.NVMfListener).Spec.Id.Value)
+		}
+	}
+	for _, m := range r.hosts.values() {
+		if m.(*bridge.NVMfHost).Spec.SubsystemId.GetValue() == subsystemID {
+			children = append(children, "host "+m.(*bridge.NVMfHost).Spec.Id.Value)
+		}
+	}
+	return children
+}
+
+// allowAnyHost tells whether any host may connect to the subsystem, which
+// is the case until access to it is restricted
+func (r *registry) allowAnyHost(subsystemID string) bool {
+	m, ok := r.accesses.load(subsystemID)
+	if !ok {
+		return true
+	}
+	return m.(*bridge.NVMfSubsystemAccess).AllowAnyHost
+}
+
+// cntlidRange returns the controller ID range the subsystem was created
+// with, the SPDK default when both ends are 0
+func (r *registry) cntlidRange(subsystemID string) *bridge.NVMfSubsystem {
+	m, ok := r.cntlidRanges.load(subsystemID)
+	if !ok {
+		return &bridge.NVMfSubsystem{}
+	}
+	return m.(*bridge.NVMfSubsystem)
+}
+
+// visibility tells which controllers and hosts see the namespace: all of
+// them when it was created without a controller, otherwise that
+// controller until the list is updated
+func (r *registry) visibility(namespace *pb.NVMeNamespace) *bridge.NVMeNamespaceVisibility {
+	if namespace.Spec.ControllerId == nil {
+		return &bridge.NVMeNamespaceVisibility{NamespaceId: namespace.Spec.Id, AnyHost: true}
+	}
+	m, ok := r.visibilities.load(namespace.Spec.Id.Value)
+	if !ok {
+		return &bridge.NVMeNamespaceVisibility{
+			NamespaceId:   namespace.Spec.Id,
+			ControllerIds: []*pc.ObjectKey{namespace.Spec.ControllerId},
+		}
+	}
+	return m.(*bridge.NVMeNamespaceVisibility)
+}
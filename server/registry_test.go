@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistry_ObjectTable(t *testing.T) {
+	r, err := newRegistry(newMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	subsys := testSubsystem()
+	if err := r.subsystems.put(ctx, "subsystem-test", subsys, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// neither the stored nor the loaded object alias the caller's
+	subsys.Spec.Nqn = "changed"
+	got, ok := r.subsystem("subsystem-test")
+	if !ok || got.Spec.Nqn != testNqn {
+		t.Fatalf("unexpected subsystem %v", got)
+	}
+	got.Spec.Nqn = "changed"
+	if again, _ := r.subsystem("subsystem-test"); again.Spec.Nqn != testNqn {
+		t.Errorf("stored subsystem changed through a loaded copy: %v", again)
+	}
+
+	if err := r.subsystems.put(ctx, "a", testSubsystem(), nil); err != nil {
+		t.Fatal(err)
+	}
+	if values := r.subsystems.values(); len(values) != 2 {
+		t.Errorf("unexpected values %v", values)
+	}
+	if err := r.subsystems.remove(ctx, "a", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.subsystem("a"); ok {
+		t.Error("removed subsystem still found")
+	}
+}
+
+// TestRegistry_Concurrent fires concurrent Create/Delete/List calls at the
+// bridge and is meant to be run with -race
+func TestRegistry_Concurrent(t *testing.T) {
+	_, c := startBridge(t)
+	ctx := context.Background()
+	const workers, rounds = 8, 10
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			subsysID := fmt.Sprintf("subsystem-%d", w)
+			namespaceID := fmt.Sprintf("namespace-%d", w)
+			controllerID := fmt.Sprintf("controller-%d", w)
+			for i := 0; i < rounds; i++ {
+				subsys := &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{
+					Id:  &pc.ObjectKey{Value: subsysID},
+					Nqn: fmt.Sprintf("nqn.2022-09.io.spdk:opi-%d", w),
+				}}
+				if _, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: subsys}); err != nil {
+					t.Errorf("create subsystem %s: %v", subsysID, err)
+					return
+				}
+				controller := &pb.NVMeController{Spec: &pb.NVMeControllerSpec{
+					Id:          &pc.ObjectKey{Value: controllerID},
+					SubsystemId: &pc.ObjectKey{Value: subsysID},
+					PcieId:      &pb.PciEndpoint{PortId: int32(w)},
+				}}
+				if _, err := c.nvme.CreateNVMeController(ctx, &pb.CreateNVMeControllerRequest{Controller: controller}); err != nil {
+					t.Errorf("create controller %s: %v", controllerID, err)
+				}
+				namespace := &pb.NVMeNamespace{Spec: &pb.NVMeNamespaceSpec{
+					Id:          &pc.ObjectKey{Value: namespaceID},
+					SubsystemId: &pc.ObjectKey{Value: subsysID},
+					HostNsid:    1,
+					VolumeId:    &pc.ObjectKey{Value: "Malloc1"},
+				}}
+				if _, err := c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: namespace}); err != nil {
+					t.Errorf("create namespace %s: %v", namespaceID, err)
+				}
+
+				if _, err := c.nvme.ListNVMeSubsystem(ctx, &pb.ListNVMeSubsystemRequest{}); err != nil {
+					t.Errorf("list subsystems: %v", err)
+				}
+				if _, err := c.nvme.ListNVMeController(ctx, &pb.ListNVMeControllerRequest{}); err != nil {
+					t.Errorf("list controllers: %v", err)
+				}
+				if _, err := c.nvme.ListNVMeNamespace(ctx, &pb.ListNVMeNamespaceRequest{}); err != nil {
+					t.Errorf("list namespaces: %v", err)
+				}
+
+				if _, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: namespaceID}}); err != nil {
+					t.Errorf("delete namespace %s: %v", namespaceID, err)
+				}
+				if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: controllerID}}); err != nil {
+					t.Errorf("delete controller %s: %v", controllerID, err)
+				}
+				if _, err := c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: subsysID}}); err != nil {
+					t.Errorf("delete subsystem %s: %v", subsysID, err)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	r := c.server.registry
+	if n := len(r.subsystems.values()) + len(r.controllers.values()) + len(r.namespaces.values()); n != 0 {
+		t.Errorf("expected an empty registry, %d objects left", n)
+	}
+}
+
+// TestRegistry_SlowApply checks that an object waiting for SPDK holds up
+// neither other objects nor callers of its own ID past their deadline
+func TestRegistry_SlowApply(t *testing.T) {
+	db, err := newBoltStore(filepath.Join(t.TempDir(), "bridge.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.close()
+	r, err := newRegistry(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	release := make(chan struct{})
+	slow := make(chan error, 1)
+	go func() {
+		slow <- r.subsystems.put(ctx, "slow", testSubsystem(), func() error {
+			<-release
+			return nil
+		})
+	}()
+	for {
+		r.subsystems.mu.Lock()
+		_, busy := r.subsystems.busy["slow"]
+		r.subsystems.mu.Unlock()
+		if busy {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan error, 2)
+	go func() {
+		done <- r.subsystems.put(ctx, "fast", testSubsystem(), func() error { return nil })
+	}()
+	go func() {
+		done <- r.namespaces.put(ctx, "namespace-test", testNamespace(), func() error { return nil })
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatal(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("a create waited for the SPDK call of another object")
+		}
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := r.subsystems.remove(timeout, "slow", nil); status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected DeadlineExceeded, got %v", err)
+	}
+
+	close(release)
+	if err := <-slow; err != nil {
+		t.Fatal(err)
+	}
+	if values := r.subsystems.values(); len(values) != 2 {
+		t.Errorf("unexpected subsystems %v", values)
+	}
+}
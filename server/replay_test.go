@@ -0,0 +1,304 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// goldenDir holds request/response pairs captured with -rpc_record
+// against a specific SPDK release
+const goldenDir = "testdata/spdk-v22.09"
+
+var goldenSpdk = flag.String("golden_spdk", "", "SPDK JSON RPC server the replay tests record their golden files from instead of replaying them, see scripts/record-golden.sh")
+
+var errReplayMismatch = errors.New("request does not match the recorded one")
+
+// replayTransport answers requests from golden files in the recorded
+// order and fails the test on any request that does not match, or when
+// recorded requests are left unsent at the end of the test
+type replayTransport struct {
+	report func(format string, args ...interface{})
+
+	mu        sync.Mutex
+	exchanges []rpcExchange
+	next      int
+}
+
+func newReplayTransport(t *testing.T, files ...string) *replayTransport {
+	r := &replayTransport{report: t.Errorf}
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join(goldenDir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.exchanges = append(r.exchanges, decodeExchanges(t, data)...)
+	}
+	t.Cleanup(func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		for _, e := range r.exchanges[r.next:] {
+			t.Errorf("recorded request never sent: %s", e.Request)
+		}
+	})
+	return r
+}
+
+// goldenTransport replays file, or records it from -golden_spdk. Golden
+// files are only ever recorded, so the test is skipped until file is.
+func goldenTransport(t *testing.T, file string) rpcTransport {
+	if *goldenSpdk == "" {
+		if _, err := os.Stat(filepath.Join(goldenDir, file)); errors.Is(err, os.ErrNotExist) {
+			t.Skipf("%s was not recorded yet, record it with scripts/record-golden.sh", filepath.Join(goldenDir, file))
+		}
+		return newReplayTransport(t, file)
+	}
+	transport, err := newTransport(*goldenSpdk, "", os.Getenv("SPDK_RPC_PASSWORD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.Create(filepath.Join(goldenDir, file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { out.Close() })
+	return newRecordingTransport(transport, out)
+}
+
+func decodeExchanges(t *testing.T, data []byte) []rpcExchange {
+	var exchanges []rpcExchange
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var e rpcExchange
+		err := decoder.Decode(&e)
+		if err == io.EOF {
+			return exchanges
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		exchanges = append(exchanges, e)
+	}
+}
+
+func (r *replayTransport) exchange(_ context.Context, id int32, data []byte) (*rpcResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.next >= len(r.exchanges) {
+		r.report("unexpected request: %s", data)
+		return nil, errReplayMismatch
+	}
+	e := r.exchanges[r.next]
+	r.next++
+	if !sameRequest(e.Request, data) {
+		r.report("request mismatch:\n got: %s\nwant: %s", data, e.Request)
+		return nil, errReplayMismatch
+	}
+	var response rpcResponse
+	if err := json.Unmarshal(e.Response, &response); err != nil {
+		r.report("invalid recorded response: %v", err)
+		return nil, err
+	}
+	response.ID = id
+	return &response, nil
+}
+
+// sameRequest compares two JSON RPC requests ignoring their IDs
+func sameRequest(a, b json.RawMessage) bool {
+	var x, y map[string]interface{}
+	if json.Unmarshal(a, &x) != nil || json.Unmarshal(b, &y) != nil {
+		return false
+	}
+	delete(x, "id")
+	delete(y, "id")
+	return reflect.DeepEqual(x, y)
+}
+
+func TestReplay_NVMeSubsystem(t *testing.T) {
+	rpc = goldenTransport(t, "nvme_subsystem.json")
+	c := serveBridge(t)
+	ctx := context.Background()
+
+	if _, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: testSubsystem()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: testNamespace()}); err != nil {
+		t.Fatal(err)
+	}
+	subsys, err := c.nvme.GetNVMeSubsystem(ctx, &pb.GetNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(subsys.Spec, testSubsystem().Spec) {
+		t.Errorf("unexpected subsystem %v", subsys)
+	}
+	list, err := c.nvme.ListNVMeNamespace(ctx, &pb.ListNVMeNamespaceRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Namespaces) != 1 || list.Namespaces[0].Spec.HostNsid != 1 {
+		t.Fatalf("unexpected namespaces %v", list.Namespaces)
+	}
+	if spec := list.Namespaces[0].Spec; spec.Id.Value != "namespace-test" || spec.BlocksCount != 131072 ||
+		spec.Uuid.GetValue() == "" {
+		t.Errorf("unexpected namespace %v", spec)
+	}
+	if _, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	// the recorded failure of deleting the subsystem twice, with the
+	// bridge state restored to still know about it
+	if err := c.server.registry.subsystems.put(ctx, "subsystem-test", testSubsystem(), nil); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestReplay_NullDebug(t *testing.T) {
+	rpc = goldenTransport(t, "bdev_null.json")
+	c := serveBridge(t)
+	ctx := context.Background()
+
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}}); err != nil {
+		t.Fatal(err)
+	}
+	device, err := c.null.NullDebugGet(ctx, &pb.NullDebugGetRequest{Handle: &pc.ObjectKey{Value: "Null42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if device.Handle.Value != "Null42" || device.Uuid.GetValue() == "" {
+		t.Errorf("unexpected device %v", device)
+	}
+	if _, err := c.null.NullDebugStats(ctx, &pb.NullDebugStatsRequest{Handle: &pc.ObjectKey{Value: "Null42"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.null.NullDebugDelete(ctx, &pb.NullDebugDeleteRequest{Handle: &pc.ObjectKey{Value: "Null42"}}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.null.NullDebugGet(ctx, &pb.NullDebugGetRequest{Handle: &pc.ObjectKey{Value: "Null42"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestReplay_VirtioBlk(t *testing.T) {
+	rpc = goldenTransport(t, "vhost_blk.json")
+	c := serveBridge(t)
+	ctx := context.Background()
+
+	blk := &pb.VirtioBlk{Id: &pc.ObjectKey{Value: "virtio-blk-42"}, VolumeId: &pc.ObjectKey{Value: "Malloc0"}}
+	if _, err := c.virtioBlk.CreateVirtioBlk(ctx, &pb.CreateVirtioBlkRequest{Controller: blk}); err != nil {
+		t.Fatal(err)
+	}
+	get, err := c.virtioBlk.GetVirtioBlk(ctx, &pb.GetVirtioBlkRequest{ControllerId: &pc.ObjectKey{Value: "virtio-blk-42"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Id.Value != "virtio-blk-42" {
+		t.Errorf("unexpected controller %v", get)
+	}
+	list, err := c.virtioBlk.ListVirtioBlk(ctx, &pb.ListVirtioBlkRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Controllers) != 1 {
+		t.Errorf("unexpected controllers %v", list.Controllers)
+	}
+	if _, err := c.virtioBlk.DeleteVirtioBlk(ctx, &pb.DeleteVirtioBlkRequest{ControllerId: &pc.ObjectKey{Value: "virtio-blk-42"}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplay_NVMfRemoteController(t *testing.T) {
+	rpc = goldenTransport(t, "bdev_nvme.json")
+	c := serveBridge(t)
+	ctx := context.Background()
+
+	if _, err := c.remote.NVMfRemoteControllerConnect(ctx, &pb.NVMfRemoteControllerConnectRequest{Ctrl: testRemoteController()}); err != nil {
+		t.Fatal(err)
+	}
+	get, err := c.remote.NVMfRemoteControllerGet(ctx, &pb.NVMfRemoteControllerGetRequest{Id: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if get.Ctrl.Subnqn != "OpiNvme8" {
+		t.Errorf("unexpected controller %v", get.Ctrl)
+	}
+	list, err := c.remote.NVMfRemoteControllerList(ctx, &pb.NVMfRemoteControllerListRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Ctrl) != 1 {
+		t.Errorf("unexpected controllers %v", list.Ctrl)
+	}
+	if _, err := c.remote.NVMfRemoteControllerDisconnect(ctx, &pb.NVMfRemoteControllerDisconnectRequest{Id: 8}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplay_Record(t *testing.T) {
+	var out bytes.Buffer
+	rpc = newRecordingTransport(newSocketTransport("unix", echoServer(t, 1)), &out)
+	if err := call(context.Background(), "bdev_get_bdevs", &BdevGetBdevsParams{Name: "Malloc0"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	waitDisconnected(t, rpc.(*recordingTransport).next.(*socketTransport))
+	if err := call(context.Background(), "spdk_get_version", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// what was recorded replays against the same calls
+	exchanges := decodeExchanges(t, out.Bytes())
+	if len(exchanges) != 2 {
+		t.Fatalf("expected 2 recorded exchanges, got %d", len(exchanges))
+	}
+	replay := &replayTransport{report: t.Errorf, exchanges: exchanges}
+	rpc = replay
+	var result string
+	if err := call(context.Background(), "bdev_get_bdevs", &BdevGetBdevsParams{Name: "Malloc0"}, &result); err != nil {
+		t.Fatal(err)
+	}
+	if result != "bdev_get_bdevs" {
+		t.Errorf("unexpected result %s", result)
+	}
+
+	// a request that differs from the recording fails and is reported
+	var reported []string
+	replay.report = func(format string, args ...interface{}) {
+		reported = append(reported, fmt.Sprintf(format, args...))
+	}
+	err := call(context.Background(), "spdk_get_version", &BdevGetBdevsParams{Name: "Malloc1"}, nil)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("expected mismatch to fail the call, got %v", err)
+	}
+	if len(reported) != 1 {
+		t.Errorf("expected mismatch to be reported, got %v", reported)
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"log"
+)
+
+// saga runs the SPDK calls of one handler and remembers, for every call
+// that succeeded, the call that compensates for it. Unless the saga is
+// committed, rollback runs the compensations in reverse order so that SPDK
+// ends up where it started:
+//
+//	tx := newSaga(ctx)
+//	defer tx.rollback()
+//	if err := tx.call("bdev_null_create", &params, &result, undo); err != nil {
+//		return nil, err
+//	}
+//	tx.commit()
+type saga struct {
+	ctx       context.Context
+	undo      []compensation
+	committed bool
+}
+
+// compensation undoes one completed step
+type compensation struct {
+	method string
+	run    func(ctx context.Context) error
+}
+
+func newSaga(ctx context.Context) *saga {
+	return &saga{ctx: ctx}
+}
+
+// call sends method to SPDK and, if it succeeds, registers undo to
+// compensate for it. A nil undo marks a step nothing can be done about.
+func (s *saga) call(method string, args, result interface{}, undo func(ctx context.Context) error) error {
+	if err := call(s.ctx, method, args, result); err != nil {
+		return err
+	}
+	if undo != nil {
+		s.undo = append(s.undo, compensation{method: method, run: undo})
+	}
+	return nil
+}
+
+// commit keeps every completed step
+func (s *saga) commit() {
+	s.committed = true
+}
+
+// rollback compensates for the completed steps, latest first, unless the
+// saga was committed. Compensations get a context of their own since the
+// handler's is often the reason for the failure, and their failures are
+// only logged since the handler already has an error to return.
+func (s *saga) rollback() {
+	if s.committed {
+		return
+	}
+	for i := len(s.undo) - 1; i >= 0; i-- {
+		c := s.undo[i]
+		log.Printf("rollback: compensating for %s", c.method)
+		if err := c.run(context.Background()); err != nil {
+			log.Printf("rollback: error compensating for %s: %v", c.method, err)
+		}
+	}
+	s.undo = nil
+}
+
+// undoCall returns a compensation sending method with args to SPDK
+func undoCall(method string, args interface{}) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return call(ctx, method, args, nil)
+	}
+}
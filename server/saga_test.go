@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestSaga_Rollback(t *testing.T) {
+	newFakeSpdk(t)
+	var undone []string
+	undo := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			undone = append(undone, name)
+			return errors.New("compensation failures are only logged")
+		}
+	}
+	params := &BdevGetBdevsParams{Name: "Malloc0"}
+
+	tx := newSaga(context.Background())
+	for _, step := range []string{"first", "second"} {
+		if err := tx.call("bdev_get_bdevs", params, nil, undo(step)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tx.call("bdev_get_bdevs", params, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	// a failed step has nothing to compensate for
+	if err := tx.call("bdev_get_bdevs", &BdevGetBdevsParams{Name: "Malloc9"}, nil, undo("failed")); err == nil {
+		t.Fatal("expected the missing bdev to fail the step")
+	}
+	tx.rollback()
+	tx.rollback()
+	if want := []string{"second", "first"}; !reflect.DeepEqual(undone, want) {
+		t.Errorf("expected compensations %v, got %v", want, undone)
+	}
+
+	undone = nil
+	tx = newSaga(context.Background())
+	if err := tx.call("bdev_get_bdevs", params, nil, undo("first")); err != nil {
+		t.Fatal(err)
+	}
+	tx.commit()
+	tx.rollback()
+	if len(undone) != 0 {
+		t.Errorf("committed saga compensated %v", undone)
+	}
+}
+
+func TestSaga_UpdateCrypto(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	if _, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the new base bdev does not exist, so creating the new volume fails
+	crypto := testCrypto()
+	crypto.VolumeId.Value = "Malloc9"
+	_, err := c.middleend.UpdateCrypto(ctx, &pb.UpdateCryptoRequest{Volume: crypto})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if _, err := c.middleend.GetCrypto(ctx, &pb.GetCryptoRequest{CryptoId: &pc.ObjectKey{Value: "Crypto42"}}); err != nil {
+		t.Errorf("crypto volume not restored: %v", err)
+	}
+	if spdk.called("bdev_crypto_create") != 3 {
+		t.Errorf("expected create, failed update and restore, got %d creates", spdk.called("bdev_crypto_create"))
+	}
+	if old, ok := c.server.registry.cryptos.load("Crypto42"); !ok || old.(*pb.Crypto).VolumeId.Value != "Malloc0" {
+		t.Errorf("registry changed by the failed update: %v", old)
+	}
+}
+
+func TestSaga_NullDebugUpdate(t *testing.T) {
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	device := &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: device}); err != nil {
+		t.Fatal(err)
+	}
+
+	spdk.failNext("bdev_null_create", -12, "Cannot allocate memory")
+	_, err := c.null.NullDebugUpdate(ctx, &pb.NullDebugUpdateRequest{Device: device})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted, got %v", err)
+	}
+	if _, err := c.null.NullDebugGet(ctx, &pb.NullDebugGetRequest{Handle: &pc.ObjectKey{Value: "Null42"}}); err != nil {
+		t.Errorf("null bdev not restored: %v", err)
+	}
+}
+
+func TestSaga_AioControllerUpdate(t *testing.T) {
+	_, c := startBridge(t)
+	ctx := context.Background()
+	device := &pb.AioController{Handle: &pc.ObjectKey{Value: "Aio42"}, Filename: "/tmp/aio_bdev_file"}
+	if _, err := c.aio.AioControllerCreate(ctx, &pb.AioControllerCreateRequest{Device: device}); err != nil {
+		t.Fatal(err)
+	}
+
+	// SPDK refuses an aio bdev without a file
+	_, err := c.aio.AioControllerUpdate(ctx, &pb.AioControllerUpdateRequest{Device: &pb.AioController{Handle: device.Handle}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if _, err := c.aio.AioControllerGet(ctx, &pb.AioControllerGetRequest{Handle: &pc.ObjectKey{Value: "Aio42"}}); err != nil {
+		t.Errorf("aio bdev not restored: %v", err)
+	}
+}
+
+// commitFailingStore fails every transaction whose SPDK calls succeeded,
+// like a disk going bad right after SPDK was changed
+type commitFailingStore struct {
+	objectStore
+}
+
+var errCommit = errors.New("commit failed")
+
+func (s commitFailingStore) update(fn func(tx storeTx) error) error {
+	return s.objectStore.update(func(tx storeTx) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return errCommit
+	})
+}
+
+func TestSaga_StoreFailure(t *testing.T) {
+	spdk := newFakeSpdk(t)
+	srv, err := newServer(commitFailingStore{newMemoryStore()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := serveServer(t, srv)
+	ctx := context.Background()
+
+	_, err = c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: testSubsystem()})
+	if err == nil {
+		t.Fatal("expected the commit failure to fail the call")
+	}
+	// SPDK is put back where it started
+	spdk.mu.Lock()
+	_, ok := spdk.subsystems[testNqn]
+	spdk.mu.Unlock()
+	if ok {
+		t.Error("subsystem left in SPDK")
+	}
+
+	// nothing is recorded, so namespaces go to the NQN given as subsystem ID
+	namespace := testNamespace()
+	namespace.Spec.SubsystemId.Value = testNqn
+	if err := call(ctx, "nvmf_create_subsystem", &NvmfCreateSubsystemParams{Nqn: testNqn}, nil); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: namespace})
+	if err == nil {
+		t.Fatal("expected the commit failure to fail the call")
+	}
+	spdk.mu.Lock()
+	left := len(spdk.subsystems[testNqn].namespaces)
+	spdk.mu.Unlock()
+	if left != 0 {
+		t.Errorf("namespace left in SPDK")
+	}
+	if spdk.called("nvmf_subsystem_remove_ns") != 1 {
+		t.Errorf("expected the namespace removed once, got %d", spdk.called("nvmf_subsystem_remove_ns"))
+	}
+}
@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"sync"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+var statsResolution = flag.Duration("stats_resolution", time.Second, "Shortest interval WatchStats samples at, longer ones are rounded up to multiples of it")
+
+// statsSampler samples SPDK once for all WatchStats calls. Every tick some
+// of them are due, it calls bdev_get_iostat and nvmf_get_stats once, plus
+// nvmf_subsystem_get_qpairs once per subsystem of a watched controller,
+// and sends each subscriber due its sample. It runs while there are
+// subscribers.
+type statsSampler struct {
+	registry   *registry
+	resolution time.Duration
+
+	mu          sync.Mutex
+	subscribers map[*statsSubscriber]struct{}
+	running     bool
+}
+
+// statsSubscriber is a WatchStats call, watching either a volume or a
+// controller
+type statsSubscriber struct {
+	volume     string
+	controller string
+	// ticks between samples, and until the next one
+	every, wait int
+
+	// the sample the deltas of the next one are over, once taken. The
+	// deltas of the first one are since SPDK started.
+	sampled    bool
+	prevVolume volumeSample
+	prevGroups []pollGroupStats
+	prevTime   time.Time
+
+	samples chan *bridge.StatsSample
+	err     chan error
+}
+
+func newStatsSampler(r *registry, resolution time.Duration) *statsSampler {
+	return &statsSampler{registry: r, resolution: resolution, subscribers: map[*statsSubscriber]struct{}{}}
+}
+
+// WatchStats streams samples of a volume or controller until the client
+// cancels the call
+func (s *server) WatchStats(in *bridge.WatchStatsRequest, stream bridge.StatsService_WatchStatsServer) error {
+	log.Printf("WatchStats: Received from client: %v", in)
+	sub, err := s.newStatsSubscriber(in)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return err
+	}
+	s.sampler.subscribe(sub)
+	defer s.sampler.unsubscribe(sub)
+	for {
+		select {
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		case err := <-sub.err:
+			log.Printf("error: %v", err)
+			return err
+		case sample := <-sub.samples:
+			if err := stream.Send(sample); err != nil {
+				log.Printf("error: %v", err)
+				return err
+			}
+		}
+	}
+}
+
+// newStatsSubscriber resolves the ID of in to a controller, or to a volume
+// through the namespace it may be. Any other ID is taken for a bdev, which
+// the first sample finds missing if it is not one.
+func (s *server) newStatsSubscriber(in *bridge.WatchStatsRequest) (*statsSubscriber, error) {
+	id := in.GetId().GetValue()
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing ID")
+	}
+	every := 1
+	if in.Interval != nil {
+		if err := in.Interval.CheckValid(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid interval: %v", err)
+		}
+		interval := in.Interval.AsDuration()
+		if interval < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "negative interval %v", interval)
+		}
+		every = int((interval + s.sampler.resolution - 1) / s.sampler.resolution)
+		if every < 1 {
+			every = 1
+		}
+	}
+	sub := &statsSubscriber{
+		every:   every,
+		wait:    1,
+		samples: make(chan *bridge.StatsSample, 1),
+		err:     make(chan error, 1),
+	}
+	if _, ok := s.registry.controller(id); ok {
+		sub.controller = id
+	} else if namespace, ok := s.registry.namespace(id); ok {
+		sub.volume = namespace.Spec.GetVolumeId().GetValue()
+	} else {
+		sub.volume = id
+	}
+	return sub, nil
+}
+
+func (s *statsSampler) subscribe(sub *statsSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers[sub] = struct{}{}
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+}
+
+func (s *statsSampler) unsubscribe(sub *statsSubscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, sub)
+}
+
+func (s *statsSampler) run() {
+	ticker := time.NewTicker(s.resolution)
+	defer ticker.Stop()
+	for now := range ticker.C {
+		if !s.tick(context.Background(), now) {
+			return
+		}
+	}
+}
+
+// tick samples for the subscribers due, and returns false once there are
+// no subscribers left. Subscribers stay due until a sample reached them,
+// so a tick SPDK fails is retried on the next one. SPDK is called without
+// holding s.mu, and for no longer than a tick, so that calls subscribing or
+// leaving do not wait for it.
+func (s *statsSampler) tick(ctx context.Context, now time.Time) bool {
+	due, ok := s.due()
+	if !ok {
+		return false
+	}
+	volumes, controllers := false, false
+	for _, sub := range due {
+		if sub.controller != "" {
+			controllers = true
+		} else {
+			volumes = true
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.resolution)
+	defer cancel()
+	var bdevs map[string]volumeSample
+	if volumes {
+		var result BdevGetIostatResult
+		if err := call(ctx, "bdev_get_iostat", &BdevGetIostatParams{}, &result); err != nil {
+			log.Printf("error: %v", err)
+		} else {
+			bdevs = sampleBdevs(result)
+		}
+	}
+	var groups []pollGroupStats
+	qpairs := map[string][]NvmfSubsystemGetQpairsResult{}
+	if controllers {
+		var result NvmfGetSubsystemStatsResult
+		if err := call(ctx, "nvmf_get_stats", nil, &result); err != nil {
+			log.Printf("error: %v", err)
+			controllers = false
+		} else {
+			groups = pollGroups(result)
+			s.getQpairs(ctx, due, qpairs)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sub := range due {
+		if _, ok := s.subscribers[sub]; !ok {
+			continue
+		}
+		if sub.controller == "" && bdevs != nil {
+			s.sampleVolume(sub, now, bdevs)
+		}
+		if sub.controller != "" && controllers {
+			s.sampleController(sub, now, groups, qpairs)
+		}
+	}
+	return true
+}
+
+// due counts down the ticks of the subscribers and returns those due, or
+// false when there are none left
+func (s *statsSampler) due() ([]*statsSubscriber, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.subscribers) == 0 {
+		s.running = false
+		return nil, false
+	}
+	var due []*statsSubscriber
+	for sub := range s.subscribers {
+		sub.wait--
+		if sub.wait <= 0 {
+			due = append(due, sub)
+		}
+	}
+	return due, true
+}
+
+// getQpairs adds the queue pairs of the subsystems of the controllers due
+// to qpairs, calling SPDK once per subsystem. A subsystem SPDK fails for
+// is left out.
+func (s *statsSampler) getQpairs(ctx context.Context, due []*statsSubscriber, qpairs map[string][]NvmfSubsystemGetQpairsResult) {
+	for _, sub := range due {
+		if sub.controller == "" {
+			continue
+		}
+		controller, ok := s.registry.controller(sub.controller)
+		if !ok {
+			continue
+		}
+		subsys, ok := s.registry.subsystem(controller.Spec.SubsystemId.GetValue())
+		if !ok {
+			continue
+		}
+		if _, ok := qpairs[subsys.Spec.Nqn]; ok {
+			continue
+		}
+		var connected []NvmfSubsystemGetQpairsResult
+		if err := call(ctx, "nvmf_subsystem_get_qpairs", &NvmfSubsystemGetQpairsParams{Nqn: subsys.Spec.Nqn}, &connected); err != nil {
+			log.Printf("error: %v", err)
+			continue
+		}
+		qpairs[subsys.Spec.Nqn] = connected
+	}
+}
+
+func (s *statsSampler) sampleVolume(sub *statsSubscriber, now time.Time, bdevs map[string]volumeSample) {
+	sample, ok := bdevs[sub.volume]
+	if !ok {
+		s.fail(sub, status.Errorf(codes.NotFound, "unable to find volume %s", sub.volume))
+		return
+	}
+	sub.wait = sub.every
+	stats := volumeStats(sub.volume, sub.prevVolume, sample)
+	if !s.send(sub, &bridge.StatsSample{Time: timestamppb.New(now), Stats: &bridge.StatsSample_Volume{Volume: stats}}) {
+		return
+	}
+	sub.sampled, sub.prevVolume = true, sample
+}
+
+// sampleController looks up the queue pairs of the controller's subsystem
+// in qpairs, leaving the controller due when they are missing
+func (s *statsSampler) sampleController(sub *statsSubscriber, now time.Time, groups []pollGroupStats, qpairs map[string][]NvmfSubsystemGetQpairsResult) {
+	controller, ok := s.registry.controller(sub.controller)
+	if !ok {
+		s.fail(sub, status.Errorf(codes.NotFound, "unable to find controller %s", sub.controller))
+		return
+	}
+	subsys, ok := s.registry.subsystem(controller.Spec.SubsystemId.GetValue())
+	if !ok {
+		s.fail(sub, status.Errorf(codes.NotFound, "unable to find subsystem %s", controller.Spec.SubsystemId.GetValue()))
+		return
+	}
+	addr, err := controllerAddress(controller.Spec)
+	if err != nil {
+		s.fail(sub, err)
+		return
+	}
+	connected, ok := qpairs[subsys.Spec.Nqn]
+	if !ok {
+		return
+	}
+	sub.wait = sub.every
+	admin, io := countQpairs(connected, &addr)
+	stats := &bridge.ControllerStats{
+		ControllerId: &pc.ObjectKey{Value: sub.controller},
+		AdminQpairs:  int32(admin),
+		IoQpairs:     int32(io),
+	}
+	// SPDK does not tell when it started, the first sample has no interval
+	if sub.sampled {
+		stats.PollGroups = pollGroupDeltas(sub.prevGroups, groups, now.Sub(sub.prevTime))
+		stats.Interval = durationpb.New(now.Sub(sub.prevTime))
+	} else {
+		stats.PollGroups = pollGroupDeltas(nil, groups, 0)
+	}
+	if !s.send(sub, &bridge.StatsSample{Time: timestamppb.New(now), Stats: &bridge.StatsSample_Controller{Controller: stats}}) {
+		return
+	}
+	sub.sampled, sub.prevGroups, sub.prevTime = true, groups, now
+}
+
+// pollGroupDeltas returns the poll groups with the queue pairs they took
+// on since prev, matched by name
+func pollGroupDeltas(prev, groups []pollGroupStats, interval time.Duration) []*bridge.PollGroupStats {
+	before := make(map[string]pollGroupStats, len(prev))
+	for _, g := range prev {
+		before[g.Name] = g
+	}
+	stats := make([]*bridge.PollGroupStats, len(groups))
+	for i, g := range groups {
+		stats[i] = &bridge.PollGroupStats{
+			Name:               g.Name,
+			AdminQpairs:        int64(g.AdminQpairs),
+			IoQpairs:           int64(g.IoQpairs),
+			CurrentAdminQpairs: int64(g.CurrentAdminQpairs),
+			CurrentIoQpairs:    int64(g.CurrentIoQpairs),
+			PendingBdevIo:      int64(g.PendingBdevIo),
+			AdminQpairsDelta:   int64(g.AdminQpairs - before[g.Name].AdminQpairs),
+			IoQpairsDelta:      int64(g.IoQpairs - before[g.Name].IoQpairs),
+		}
+		if interval > 0 {
+			stats[i].AdminQpairRate = float64(stats[i].AdminQpairsDelta) / interval.Seconds()
+			stats[i].IoQpairRate = float64(stats[i].IoQpairsDelta) / interval.Seconds()
+		}
+	}
+	return stats
+}
+
+// send hands sample to sub unless it has not taken the one before yet, in
+// which case the next sample covers this one's interval too
+func (s *statsSampler) send(sub *statsSubscriber, sample *bridge.StatsSample) bool {
+	select {
+	case sub.samples <- sample:
+		return true
+	default:
+		return false
+	}
+}
+
+// fail ends the WatchStats call of sub with err
+func (s *statsSampler) fail(sub *statsSubscriber, err error) {
+	delete(s.subscribers, sub)
+	sub.err <- err
+}
@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// watchStats starts watching id every interval, with the sampler ticking
+// every 10ms
+func watchStats(t *testing.T, c *bridgeClients, id string, interval time.Duration) bridge.StatsService_WatchStatsClient {
+	c.server.sampler.resolution = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	t.Cleanup(cancel)
+	stream, err := c.stats.WatchStats(ctx, &bridge.WatchStatsRequest{
+		Id:       &pc.ObjectKey{Value: id},
+		Interval: durationpb.New(interval),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stream
+}
+
+func TestSampler_WatchVolume(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestNamespace(t, c)
+	spdk.mu.Lock()
+	b := spdk.bdevs["Malloc1"]
+	b.bytesRead, b.readOps = 8192, 2
+	spdk.mu.Unlock()
+	stream := watchStats(t, c, "namespace-test", 10*time.Millisecond)
+
+	sample, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first sample is over the second the fake SPDK's clock ran
+	// since it started, like GetVolumeStats without a previous sample
+	stats := sample.GetVolume()
+	if stats.GetVolumeId().GetValue() != "Malloc1" || stats.Read.Bytes != 8192 || stats.Read.BytesDelta != 8192 || stats.Interval.AsDuration() != time.Second {
+		t.Fatalf("unexpected sample %v", sample)
+	}
+	sample, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the clock moves a second between samples
+	stats = sample.GetVolume()
+	if stats.Read.BytesDelta != 0 || stats.Interval.AsDuration() != time.Second {
+		t.Fatalf("unexpected sample %v", sample)
+	}
+
+	spdk.mu.Lock()
+	b.bytesRead, b.readOps = 24576, 6
+	spdk.mu.Unlock()
+	for i := 0; stats.Read.BytesDelta == 0; i++ {
+		if i == 10 {
+			t.Fatal("the counters did not move")
+		}
+		sample, err = stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		stats = sample.GetVolume()
+	}
+	if stats.Read.Bytes != 24576 || stats.Read.BytesDelta != 16384 || stats.Read.OperationsDelta != 4 || stats.Read.Iops != 4 || stats.Read.Bandwidth != 16384 {
+		t.Errorf("unexpected sample %v", sample)
+	}
+}
+
+func TestSampler_WatchController(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestSubsystem(t, c)
+	createTestController(t, c)
+	connectTestQpairs(spdk)
+	stream := watchStats(t, c, "controller-test", 20*time.Millisecond)
+
+	sample, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first sample has no interval, its deltas are since SPDK started
+	stats := sample.GetController()
+	if stats.GetControllerId().GetValue() != "controller-test" || stats.AdminQpairs != 1 || stats.IoQpairs != 1 ||
+		len(stats.PollGroups) != 1 || stats.PollGroups[0].CurrentAdminQpairs != 2 || stats.PollGroups[0].IoQpairsDelta != 1 ||
+		stats.Interval != nil {
+		t.Fatalf("unexpected sample %v", sample)
+	}
+	sample, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats = sample.GetController()
+	if stats.PollGroups[0].IoQpairsDelta != 0 {
+		t.Fatalf("unexpected sample %v", sample)
+	}
+	// two ticks apart, give or take the ticker's jitter
+	if d := stats.Interval.AsDuration(); d < 15*time.Millisecond {
+		t.Errorf("expected samples 20ms apart, got %v", d)
+	}
+
+	spdk.mu.Lock()
+	spdk.subsystems[testNqn].qpairs = append(spdk.subsystems[testNqn].qpairs, NvmfSubsystemGetQpairsResult{
+		Cntlid: 1, Qid: 2, State: "active",
+		ListenAddress: NvmfListenAddress{Trtype: "VFIOUSER", Traddr: filepath.Join(*vfiouserDir, "port0-pf1-vf0"), Trsvcid: "0"},
+	})
+	spdk.mu.Unlock()
+	for i := 0; stats.IoQpairs == 1; i++ {
+		if i == 10 {
+			t.Fatal("the queue pair did not show")
+		}
+		sample, err = stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		stats = sample.GetController()
+	}
+	if stats.IoQpairs != 2 || stats.PollGroups[0].IoQpairsDelta != 1 || stats.PollGroups[0].IoQpairRate <= 0 {
+		t.Errorf("unexpected sample %v", sample)
+	}
+}
+
+func TestSampler_WatchInvalid(t *testing.T) {
+	_, c := startBridge(t)
+	tests := map[string]struct {
+		id       string
+		interval time.Duration
+		code     codes.Code
+	}{
+		"missing ID":        {"", time.Second, codes.InvalidArgument},
+		"negative interval": {"Malloc0", -time.Second, codes.InvalidArgument},
+		"unknown volume":    {"unknown", time.Millisecond, codes.NotFound},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stream := watchStats(t, c, tt.id, tt.interval)
+			if _, err := stream.Recv(); status.Code(err) != tt.code {
+				t.Errorf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestSampler_Shared(t *testing.T) {
+	spdk := newFakeSpdk(t)
+	srv, err := newServer(newMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := srv.sampler
+	subscribe := func(id string, interval time.Duration) *statsSubscriber {
+		sub, err := srv.newStatsSubscriber(&bridge.WatchStatsRequest{Id: &pc.ObjectKey{Value: id}, Interval: durationpb.New(interval)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// ticked by hand instead of by a running sampler
+		s.subscribers[sub] = struct{}{}
+		return sub
+	}
+	fast0 := subscribe("Malloc0", s.resolution)
+	fast1 := subscribe("Malloc1", s.resolution/2)
+	slow := subscribe("Malloc0", 2*s.resolution)
+	unknown := subscribe("unknown", s.resolution)
+	ctx := context.Background()
+
+	// every subscriber gets a sample on the first tick
+	for i, want := range []int{1, 0, 1} {
+		s.tick(ctx, time.Now())
+		for _, sub := range []*statsSubscriber{fast0, fast1} {
+			if len(sub.samples) != 1 {
+				t.Fatalf("tick %d: expected a sample of %s", i, sub.volume)
+			}
+			<-sub.samples
+		}
+		if len(slow.samples) != want {
+			t.Errorf("tick %d: expected %d samples of the slow subscriber, got %d", i, want, len(slow.samples))
+		}
+		if len(slow.samples) != 0 {
+			<-slow.samples
+		}
+	}
+	if err := <-unknown.err; status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	if calls := spdk.called("bdev_get_iostat"); calls != 3 {
+		t.Errorf("expected one call a tick, got %d", calls)
+	}
+
+	for sub := range s.subscribers {
+		s.unsubscribe(sub)
+	}
+	if s.tick(ctx, time.Now()) {
+		t.Error("expected the sampler to stop without subscribers")
+	}
+}
+
+// a tick stuck in SPDK neither holds up subscribing nor outlasts the tick
+func TestSampler_TickUnlocked(t *testing.T) {
+	srv, err := newServer(newMemoryStore())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpc = newSocketTransport("unix", hungServer(t))
+	s := srv.sampler
+	s.resolution = 100 * time.Millisecond
+	sub, err := srv.newStatsSubscriber(&bridge.WatchStatsRequest{Id: &pc.ObjectKey{Value: "Malloc0"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.subscribers[sub] = struct{}{}
+
+	done := make(chan bool, 1)
+	go func() { done <- s.tick(context.Background(), time.Now()) }()
+	other, err := srv.newStatsSubscriber(&bridge.WatchStatsRequest{Id: &pc.ObjectKey{Value: "Malloc1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscribed := make(chan struct{})
+	go func() {
+		s.mu.Lock()
+		s.subscribers[other] = struct{}{}
+		s.mu.Unlock()
+		s.unsubscribe(other)
+		close(subscribed)
+	}()
+	select {
+	case <-subscribed:
+	case <-time.After(s.resolution / 2):
+		t.Error("subscribing waited for SPDK")
+	}
+	select {
+	case running := <-done:
+		if !running {
+			t.Error("expected the sampler to keep running")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the tick outlasted its deadline")
+	}
+	if sub.wait > 0 || len(sub.samples) != 0 {
+		t.Errorf("expected the subscriber still due, wait %d, %d samples", sub.wait, len(sub.samples))
+	}
+}
@@ -4,18 +4,27 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"os"
+	"time"
 
 	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
+	bridge "opi.storage.v1/proto/gen/go"
 )
 
 var (
-	port = flag.Int("port", 50051, "The server port")
+	port     = flag.Int("port", 50051, "The server port")
+	httpPort = flag.Int("http_port", 8082, "The port of the REST endpoints")
 )
 
 type server struct {
@@ -26,28 +35,115 @@ type server struct {
 	pb.UnimplementedNullDebugServiceServer
 	pb.UnimplementedAioControllerServiceServer
 	pb.UnimplementedMiddleendServiceServer
+	bridge.UnimplementedNVMfListenerServiceServer
+	bridge.UnimplementedNVMfHostServiceServer
+	bridge.UnimplementedNVMfTransportServiceServer
+	bridge.UnimplementedNVMeNamespaceVisibilityServiceServer
+	bridge.UnimplementedStatsServiceServer
+	bridge.UnimplementedNVMfSubsystemServiceServer
+	bridge.UnimplementedVolumeStatsServiceServer
+
+	registry  *registry
+	endpoints endpointClaims
+	sampler   *statsSampler
+}
+
+// newServer serves the objects saved in db
+func newServer(db objectStore) (*server, error) {
+	r, err := newRegistry(db)
+	if err != nil {
+		return nil, err
+	}
+	return &server{
+		registry:  r,
+		endpoints: endpointClaims{creating: map[string]bool{}},
+		sampler:   newStatsSampler(r, *statsResolution),
+	}, nil
 }
 
 func main() {
 	flag.Parse()
+	tp, err := newTracerProvider(*otlpEndpoint, *traceFile)
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	// log.Fatalf skips deferred calls, so flush the traces before exiting
+	fatalf := func(format string, v ...interface{}) {
+		shutdownTracing(tp)
+		log.Fatalf(format, v...)
+	}
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	password, err := rpcPassword()
+	if err != nil {
+		fatalf("failed to read SPDK JSON RPC password: %v", err)
+	}
+	rpc, err = newTransport(*rpcSock, *rpcCA, password)
+	if err != nil {
+		fatalf("failed to set up SPDK JSON RPC: %v", err)
+	}
+	if *rpcRecord != "" {
+		f, err := os.OpenFile(*rpcRecord, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			fatalf("failed to open SPDK JSON RPC record file: %v", err)
+		}
+		rpc = newRecordingTransport(rpc, f)
+	}
+	db, err := newStore(*dbFile)
+	if err != nil {
+		fatalf("failed to open object store: %v", err)
+	}
+	srv, err := newServer(db)
+	if err != nil {
+		fatalf("failed to load objects: %v", err)
+	}
+	rec, err := newReconciler(srv, *reconcilePolicy)
+	if err != nil {
+		fatalf("failed to set up reconciliation: %v", err)
+	}
+	go rec.run(context.Background(), *reconcileInterval)
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/reconcile", rec)
+	mux.Handle("/metrics", newMetricsHandler(srv))
+	go func() {
+		h := &http.Server{Addr: fmt.Sprintf(":%d", *httpPort), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+		log.Printf("REST endpoints listening at %v", h.Addr)
+		if err := h.ListenAndServe(); err != nil {
+			fatalf("failed to serve REST endpoints: %v", err)
+		}
+	}()
+
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
+	cache := newRequestCache(*requestIDWindow)
+	s := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(metricsUnaryInterceptor, cache.unaryInterceptor),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor),
+	)
 
-	pb.RegisterFrontendNvmeServiceServer(s, &server{})
-	pb.RegisterNVMfRemoteControllerServiceServer(s, &server{})
-	pb.RegisterFrontendVirtioBlkServiceServer(s, &server{})
-	pb.RegisterFrontendVirtioScsiServiceServer(s, &server{})
-	pb.RegisterNullDebugServiceServer(s, &server{})
-	pb.RegisterAioControllerServiceServer(s, &server{})
-	pb.RegisterMiddleendServiceServer(s, &server{})
+	pb.RegisterFrontendNvmeServiceServer(s, srv)
+	pb.RegisterNVMfRemoteControllerServiceServer(s, srv)
+	pb.RegisterFrontendVirtioBlkServiceServer(s, srv)
+	pb.RegisterFrontendVirtioScsiServiceServer(s, srv)
+	pb.RegisterNullDebugServiceServer(s, srv)
+	pb.RegisterAioControllerServiceServer(s, srv)
+	pb.RegisterMiddleendServiceServer(s, srv)
+	bridge.RegisterNVMfListenerServiceServer(s, srv)
+	bridge.RegisterNVMfHostServiceServer(s, srv)
+	bridge.RegisterNVMfTransportServiceServer(s, srv)
+	bridge.RegisterNVMeNamespaceVisibilityServiceServer(s, srv)
+	bridge.RegisterStatsServiceServer(s, srv)
+	bridge.RegisterNVMfSubsystemServiceServer(s, srv)
+	bridge.RegisterVolumeStatsServiceServer(s, srv)
 
 	reflection.Register(s)
 
 	log.Printf("server listening at %v", lis.Addr())
 	if err := s.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+		fatalf("failed to serve: %v", err)
 	}
 }
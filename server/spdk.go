@@ -164,7 +164,7 @@ type BdevGetBdevsResult struct {
 
 // BdevGetIostatParams hold the parameters required to get the IO stats of a block device
 type BdevGetIostatParams struct {
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 }
 
 // BdevGetIostatResult hold the results of getting the IO stats of a block device
@@ -236,6 +236,12 @@ type NvmfSubsystemAddNsParams struct {
 	Nqn       string `json:"nqn"`
 	Namespace struct {
 		BdevName string `json:"bdev_name"`
+		Nsid     int    `json:"nsid,omitempty"`
+		Nguid    string `json:"nguid,omitempty"`
+		Eui64    string `json:"eui64,omitempty"`
+		UUID     string `json:"uuid,omitempty"`
+		// the namespace is seen by the hosts added to it only
+		NoAutoVisible bool `json:"no_auto_visible,omitempty"`
 	} `json:"namespace"`
 }
 
@@ -253,9 +259,13 @@ type NvmfSubsystemRemoveNsResult bool
 
 // NvmfCreateSubsystemParams holds the parameters required to create a NVMf subsystem
 type NvmfCreateSubsystemParams struct {
-	Nqn          string `json:"nqn"`
-	SerialNumber string `json:"serial_number"`
-	AllowAnyHost bool   `json:"allow_any_host"`
+	Nqn           string `json:"nqn"`
+	SerialNumber  string `json:"serial_number,omitempty"`
+	ModelNumber   string `json:"model_number,omitempty"`
+	MaxNamespaces int    `json:"max_namespaces,omitempty"`
+	AllowAnyHost  bool   `json:"allow_any_host"`
+	MinCntlid     int    `json:"min_cntlid,omitempty"`
+	MaxCntlid     int    `json:"max_cntlid,omitempty"`
 }
 
 // NvmfCreateSubsystemResult is the result of creating a NVMf subsystem
@@ -271,20 +281,167 @@ type NvmfDeleteSubsystemResult bool
 
 // NvmfGetSubsystemsResult is the result of listing all NVMf subsystems
 type NvmfGetSubsystemsResult struct {
-	Nqn             string        `json:"nqn"`
-	Subtype         string        `json:"subtype"`
-	ListenAddresses []interface{} `json:"listen_addresses"`
-	AllowAnyHost    bool          `json:"allow_any_host"`
-	Hosts           []interface{} `json:"hosts"`
-	SerialNumber    string        `json:"serial_number,omitempty"`
-	ModelNumber     string        `json:"model_number,omitempty"`
-	MaxNamespaces   int           `json:"max_namespaces,omitempty"`
-	MinCntlid       int           `json:"min_cntlid,omitempty"`
-	MaxCntlid       int           `json:"max_cntlid,omitempty"`
-	Namespaces      []struct {
-		Nsid int    `json:"nsid"`
-		Name string `json:"name"`
-	} `json:"namespaces,omitempty"`
+	Nqn             string                   `json:"nqn"`
+	Subtype         string                   `json:"subtype"`
+	ListenAddresses []NvmfListenAddress      `json:"listen_addresses"`
+	AllowAnyHost    bool                     `json:"allow_any_host"`
+	Hosts           []NvmfSubsystemHost      `json:"hosts"`
+	SerialNumber    string                   `json:"serial_number,omitempty"`
+	ModelNumber     string                   `json:"model_number,omitempty"`
+	MaxNamespaces   int                      `json:"max_namespaces,omitempty"`
+	MinCntlid       int                      `json:"min_cntlid,omitempty"`
+	MaxCntlid       int                      `json:"max_cntlid,omitempty"`
+	Namespaces      []NvmfSubsystemNamespace `json:"namespaces,omitempty"`
+}
+
+// NvmfSubsystemNamespace is a namespace of a NVMf subsystem
+type NvmfSubsystemNamespace struct {
+	Nsid     int    `json:"nsid"`
+	BdevName string `json:"bdev_name"`
+	Name     string `json:"name"`
+	Nguid    string `json:"nguid,omitempty"`
+	Eui64    string `json:"eui64,omitempty"`
+	UUID     string `json:"uuid,omitempty"`
+}
+
+// NvmfSubsystemHost is a host allowed to connect to a NVMf subsystem
+type NvmfSubsystemHost struct {
+	Nqn string `json:"nqn"`
+}
+
+// NvmfSubsystemAllowAnyHostParams holds the parameters required to let any host connect to a NVMf subsystem
+type NvmfSubsystemAllowAnyHostParams struct {
+	Nqn          string `json:"nqn"`
+	AllowAnyHost bool   `json:"allow_any_host"`
+}
+
+// NvmfSubsystemAllowAnyHostResult is the result of letting any host connect to a NVMf subsystem
+type NvmfSubsystemAllowAnyHostResult bool
+
+// NvmfSubsystemAddHostParams holds the parameters required to allow a host to connect to a NVMf subsystem
+type NvmfSubsystemAddHostParams struct {
+	Nqn  string `json:"nqn"`
+	Host string `json:"host"`
+}
+
+// NvmfSubsystemAddHostResult is the result of allowing a host to connect to a NVMf subsystem
+type NvmfSubsystemAddHostResult bool
+
+// NvmfSubsystemRemoveHostParams holds the parameters required to disallow a host to connect to a NVMf subsystem
+type NvmfSubsystemRemoveHostParams struct {
+	Nqn  string `json:"nqn"`
+	Host string `json:"host"`
+}
+
+// NvmfSubsystemRemoveHostResult is the result of disallowing a host to connect to a NVMf subsystem
+type NvmfSubsystemRemoveHostResult bool
+
+// NvmfNsAddHostParams holds the parameters required to make a namespace visible to a host
+type NvmfNsAddHostParams struct {
+	Nqn  string `json:"nqn"`
+	Nsid int    `json:"nsid"`
+	Host string `json:"host"`
+}
+
+// NvmfNsAddHostResult is the result of making a namespace visible to a host
+type NvmfNsAddHostResult bool
+
+// NvmfNsRemoveHostParams holds the parameters required to hide a namespace from a host
+type NvmfNsRemoveHostParams struct {
+	Nqn  string `json:"nqn"`
+	Nsid int    `json:"nsid"`
+	Host string `json:"host"`
+}
+
+// NvmfNsRemoveHostResult is the result of hiding a namespace from a host
+type NvmfNsRemoveHostResult bool
+
+// NvmfListenAddress is a transport address a NVMf subsystem listens on
+type NvmfListenAddress struct {
+	Trtype  string `json:"trtype"`
+	Adrfam  string `json:"adrfam,omitempty"`
+	Traddr  string `json:"traddr"`
+	Trsvcid string `json:"trsvcid,omitempty"`
+}
+
+// NvmfSubsystemAddListenerParams holds the parameters required to add a listener to a NVMf subsystem
+type NvmfSubsystemAddListenerParams struct {
+	Nqn           string            `json:"nqn"`
+	ListenAddress NvmfListenAddress `json:"listen_address"`
+}
+
+// NvmfSubsystemAddListenerResult is the result of adding a listener to a NVMf subsystem
+type NvmfSubsystemAddListenerResult bool
+
+// NvmfSubsystemRemoveListenerParams holds the parameters required to remove a listener from a NVMf subsystem
+type NvmfSubsystemRemoveListenerParams struct {
+	Nqn           string            `json:"nqn"`
+	ListenAddress NvmfListenAddress `json:"listen_address"`
+}
+
+// NvmfSubsystemRemoveListenerResult is the result of removing a listener from a NVMf subsystem
+type NvmfSubsystemRemoveListenerResult bool
+
+// NvmfSubsystemListenerSetAnaStateParams holds the parameters required to set the ANA state of a listener
+type NvmfSubsystemListenerSetAnaStateParams struct {
+	Nqn           string            `json:"nqn"`
+	ListenAddress NvmfListenAddress `json:"listen_address"`
+	AnaState      string            `json:"ana_state"`
+}
+
+// NvmfSubsystemListenerSetAnaStateResult is the result of setting the ANA state of a listener
+type NvmfSubsystemListenerSetAnaStateResult bool
+
+// NvmfSubsystemGetListenersParams holds the parameters required to list the listeners of a NVMf subsystem
+type NvmfSubsystemGetListenersParams struct {
+	Nqn string `json:"nqn"`
+}
+
+// NvmfSubsystemGetListenersResult is the result of listing the listeners of a NVMf subsystem
+type NvmfSubsystemGetListenersResult struct {
+	Address   NvmfListenAddress `json:"address"`
+	AnaStates []struct {
+		AnaGroup int    `json:"ana_group"`
+		AnaState string `json:"ana_state"`
+	} `json:"ana_states"`
+}
+
+// NvmfSubsystemGetQpairsParams holds the parameters required to list the queue pairs of a NVMf subsystem
+type NvmfSubsystemGetQpairsParams struct {
+	Nqn string `json:"nqn"`
+}
+
+// NvmfSubsystemGetQpairsResult is a queue pair of a NVMf subsystem, the admin queue pair having qid 0
+type NvmfSubsystemGetQpairsResult struct {
+	Cntlid        int               `json:"cntlid"`
+	Qid           int               `json:"qid"`
+	State         string            `json:"state"`
+	ListenAddress NvmfListenAddress `json:"listen_address"`
+}
+
+// NvmfCreateTransportParams holds the parameters required to create a NVMf transport
+type NvmfCreateTransportParams struct {
+	Trtype              string `json:"trtype"`
+	IoUnitSize          int    `json:"io_unit_size,omitempty"`
+	MaxIoQpairsPerCtrlr int    `json:"max_io_qpairs_per_ctrlr,omitempty"`
+	InCapsuleDataSize   *int   `json:"in_capsule_data_size,omitempty"`
+	NumSharedBuffers    int    `json:"num_shared_buffers,omitempty"`
+	SockPriority        int    `json:"sock_priority,omitempty"`
+}
+
+// NvmfCreateTransportResult is the result of creating a NVMf transport
+type NvmfCreateTransportResult bool
+
+// NvmfGetTransportsResult is the result of listing all NVMf transports
+type NvmfGetTransportsResult struct {
+	Trtype              string `json:"trtype"`
+	MaxQueueDepth       int    `json:"max_queue_depth"`
+	MaxIoQpairsPerCtrlr int    `json:"max_io_qpairs_per_ctrlr"`
+	InCapsuleDataSize   int    `json:"in_capsule_data_size"`
+	MaxIoSize           int    `json:"max_io_size"`
+	IoUnitSize          int    `json:"io_unit_size"`
+	NumSharedBuffers    int    `json:"num_shared_buffers"`
+	SockPriority        int    `json:"sock_priority,omitempty"`
 }
 
 // NvmfGetSubsystemStatsResult is the result of NVMf subsystem statistics
@@ -1,9 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
 package main
 
 import (
+	"context"
 	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestSpdk_Call(t *testing.T) {
+	spdk := newFakeSpdk(t)
+	ctx := context.Background()
+
+	var bdevs []BdevGetBdevsResult
+	if err := call(ctx, "bdev_get_bdevs", &BdevGetBdevsParams{Name: "Malloc0"}, &bdevs); err != nil {
+		t.Fatal(err)
+	}
+	if len(bdevs) != 1 || bdevs[0].Name != "Malloc0" || bdevs[0].BlockSize != 512 || bdevs[0].UUID == "" {
+		t.Errorf("unexpected bdevs %v", bdevs)
+	}
+
+	var nsid NvmfSubsystemAddNsResult
+	if err := call(ctx, "nvmf_create_subsystem", &NvmfCreateSubsystemParams{Nqn: testNqn}, nil); err != nil {
+		t.Fatal(err)
+	}
+	params := NvmfSubsystemAddNsParams{Nqn: testNqn}
+	params.Namespace.BdevName = "Malloc0"
+	if err := call(ctx, "nvmf_subsystem_add_ns", &params, &nsid); err != nil {
+		t.Fatal(err)
+	}
+	if nsid != 1 {
+		t.Errorf("expected nsid 1, got %d", nsid)
+	}
+
+	err := call(ctx, "spdk_kill_instance", nil, nil)
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected Unimplemented, got %v", err)
+	}
 
+	spdk.setError("bdev_get_bdevs", fakeENODEV, "No such device")
+	err = call(ctx, "bdev_get_bdevs", nil, &bdevs)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+	if spdk.called("bdev_get_bdevs") != 2 {
+		t.Errorf("expected 2 calls, got %d", spdk.called("bdev_get_bdevs"))
+	}
 }
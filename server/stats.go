@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// qpairStats count the queue pairs of a subsystem, or of one controller of
+// it, next to the counters of the target's poll groups, which SPDK keeps
+// for the whole target only
+type qpairStats struct {
+	TickRate    int              `json:"tick_rate"`
+	AdminQpairs int              `json:"admin_qpairs"`
+	IoQpairs    int              `json:"io_qpairs"`
+	PollGroups  []pollGroupStats `json:"poll_groups"`
+}
+
+type pollGroupStats struct {
+	Name               string `json:"name"`
+	AdminQpairs        int    `json:"admin_qpairs"`
+	IoQpairs           int    `json:"io_qpairs"`
+	CurrentAdminQpairs int    `json:"current_admin_qpairs"`
+	CurrentIoQpairs    int    `json:"current_io_qpairs"`
+	PendingBdevIo      int    `json:"pending_bdev_io"`
+}
+
+// getQpairStats returns the queue pairs of the subsystem nqn, only those
+// on addr unless it is nil
+func getQpairStats(ctx context.Context, nqn string, addr *NvmfListenAddress) (*qpairStats, error) {
+	var result NvmfGetSubsystemStatsResult
+	if err := call(ctx, "nvmf_get_stats", nil, &result); err != nil {
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	var qpairs []NvmfSubsystemGetQpairsResult
+	if err := call(ctx, "nvmf_subsystem_get_qpairs", &NvmfSubsystemGetQpairsParams{Nqn: nqn}, &qpairs); err != nil {
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", qpairs)
+	stats := &qpairStats{TickRate: result.TickRate, PollGroups: pollGroups(result)}
+	stats.AdminQpairs, stats.IoQpairs = countQpairs(qpairs, addr)
+	return stats, nil
+}
+
+// countQpairs counts the admin and I/O queue pairs among qpairs, only those
+// on addr unless it is nil
+func countQpairs(qpairs []NvmfSubsystemGetQpairsResult, addr *NvmfListenAddress) (admin, io int) {
+	for i := range qpairs {
+		if addr != nil && !sameListenAddress(*addr, qpairs[i].ListenAddress) {
+			continue
+		}
+		if qpairs[i].Qid == 0 {
+			admin++
+		} else {
+			io++
+		}
+	}
+	return admin, io
+}
+
+func pollGroups(result NvmfGetSubsystemStatsResult) []pollGroupStats {
+	groups := make([]pollGroupStats, len(result.PollGroups))
+	for i, g := range result.PollGroups {
+		groups[i] = pollGroupStats{
+			Name:               g.Name,
+			AdminQpairs:        g.AdminQpairs,
+			IoQpairs:           g.IoQpairs,
+			CurrentAdminQpairs: g.CurrentAdminQpairs,
+			CurrentIoQpairs:    g.CurrentIoQpairs,
+			PendingBdevIo:      g.PendingBdevIo,
+		}
+	}
+	return groups
+}
+
+// volumeSample holds the I/O counters of a bdev at one tick of SPDK's clock
+type volumeSample struct {
+	tickRate           int
+	ticks              int64
+	read, write, unmap ioCounters
+}
+
+type ioCounters struct {
+	bytes, ops, latencyTicks int
+}
+
+// getVolumeSample reads the I/O counters of the bdev name
+func getVolumeSample(ctx context.Context, name string) (volumeSample, error) {
+	params := BdevGetIostatParams{
+		Name: name,
+	}
+	var result BdevGetIostatResult
+	if err := call(ctx, "bdev_get_iostat", &params, &result); err != nil {
+		return volumeSample{}, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	if len(result.Bdevs) != 1 {
+		msg := fmt.Sprintf("expecting exactly 1 result, got %d", len(result.Bdevs))
+		return volumeSample{}, status.Errorf(codes.InvalidArgument, msg)
+	}
+	return sampleBdevs(result)[result.Bdevs[0].Name], nil
+}
+
+// sampleBdevs returns the samples of the bdevs in result by name
+func sampleBdevs(result BdevGetIostatResult) map[string]volumeSample {
+	samples := make(map[string]volumeSample, len(result.Bdevs))
+	for _, b := range result.Bdevs {
+		samples[b.Name] = volumeSample{
+			tickRate: result.TickRate,
+			ticks:    result.Ticks,
+			read:     ioCounters{bytes: b.BytesRead, ops: b.NumReadOps, latencyTicks: b.ReadLatencyTicks},
+			write:    ioCounters{bytes: b.BytesWritten, ops: b.NumWriteOps, latencyTicks: b.WriteLatencyTicks},
+			unmap:    ioCounters{bytes: b.BytesUnmapped, ops: b.NumUnmapOps, latencyTicks: b.UnmapLatencyTicks},
+		}
+	}
+	return samples
+}
+
+// volumeStats returns the counters of sample, with the rates over the
+// interval since prev. The interval starts when SPDK did if there is no
+// prev, or if the counters went back because the bdev was recreated.
+func volumeStats(name string, prev, sample volumeSample) *bridge.VolumeStats {
+	if prev.ticks >= sample.ticks || sample.read.ops < prev.read.ops ||
+		sample.write.ops < prev.write.ops || sample.unmap.ops < prev.unmap.ops {
+		prev = volumeSample{}
+	}
+	var seconds float64
+	if sample.tickRate != 0 {
+		seconds = float64(sample.ticks-prev.ticks) / float64(sample.tickRate)
+	}
+	return &bridge.VolumeStats{
+		VolumeId: &pc.ObjectKey{Value: name},
+		Read:     ioStats(prev.read, sample.read, sample.tickRate, seconds),
+		Write:    ioStats(prev.write, sample.write, sample.tickRate, seconds),
+		Unmap:    ioStats(prev.unmap, sample.unmap, sample.tickRate, seconds),
+		Interval: durationpb.New(time.Duration(seconds * float64(time.Second))),
+		Ticks:    sample.ticks,
+	}
+}
+
+func ioStats(prev, c ioCounters, tickRate int, seconds float64) *bridge.IoStats {
+	stats := &bridge.IoStats{
+		Bytes:      int64(c.bytes),
+		Operations: int64(c.ops),
+		LatencyUs:  ticksToMicroseconds(c.latencyTicks, tickRate),
+	}
+	stats.BytesDelta = int64(c.bytes - prev.bytes)
+	stats.OperationsDelta = int64(c.ops - prev.ops)
+	if seconds > 0 {
+		stats.Iops = float64(stats.OperationsDelta) / seconds
+		stats.Bandwidth = float64(stats.BytesDelta) / seconds
+	}
+	return stats
+}
+
+// previousSample returns the counters of stats, a sample volumeStats
+// returned before, to compute the next one's rates over
+func previousSample(stats *bridge.VolumeStats) volumeSample {
+	if stats == nil {
+		return volumeSample{}
+	}
+	return volumeSample{
+		ticks: stats.Ticks,
+		read:  ioCounters{bytes: int(stats.Read.GetBytes()), ops: int(stats.Read.GetOperations())},
+		write: ioCounters{bytes: int(stats.Write.GetBytes()), ops: int(stats.Write.GetOperations())},
+		unmap: ioCounters{bytes: int(stats.Unmap.GetBytes()), ops: int(stats.Unmap.GetOperations())},
+	}
+}
+
+// getVolumeStats samples the bdev name, with the rates since prev, or
+// since SPDK started without it
+func getVolumeStats(ctx context.Context, name string, prev *bridge.VolumeStats) (*bridge.VolumeStats, error) {
+	sample, err := getVolumeSample(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return volumeStats(name, previousSample(prev), sample), nil
+}
+
+// GetVolumeStats samples the volume, or the volume of the namespace, with
+// the rates since the sample the caller passes
+func (s *server) GetVolumeStats(ctx context.Context, in *bridge.GetVolumeStatsRequest) (*bridge.VolumeStats, error) {
+	log.Printf("GetVolumeStats: Received from client: %v", in)
+	name := in.GetId().GetValue()
+	if name == "" {
+		err := status.Error(codes.InvalidArgument, "missing ID")
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	if namespace, ok := s.registry.namespace(name); ok {
+		name = namespace.Spec.GetVolumeId().GetValue()
+	}
+	if in.Previous != nil && in.Previous.GetVolumeId().GetValue() != name {
+		err := status.Errorf(codes.InvalidArgument, "previous sample of volume %s, not %s", in.Previous.GetVolumeId().GetValue(), name)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	stats, err := getVolumeStats(ctx, name, in.Previous)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return stats, nil
+}
+
+// encodeVolumeStats encodes stats as JSON for the stats string of the OPI
+// responses
+func encodeVolumeStats(stats *bridge.VolumeStats) (string, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}.Marshal(stats)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ticksToMicroseconds converts ticks of SPDK's clock, which ticks tickRate
+// times a second
+func ticksToMicroseconds(ticks, tickRate int) float64 {
+	if tickRate == 0 {
+		return 0
+	}
+	return float64(ticks) * 1e6 / float64(tickRate)
+}
+
+// marshalStats encodes stats for the stats string of the OPI responses
+func marshalStats(stats interface{}) (string, error) {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
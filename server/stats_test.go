@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+func TestStats_VolumeStats(t *testing.T) {
+	// SPDK's clock ticks 1000 times a second
+	prev := volumeSample{tickRate: 1000, ticks: 4000, read: ioCounters{bytes: 4096, ops: 1}}
+	sample := volumeSample{tickRate: 1000, ticks: 6000, read: ioCounters{bytes: 20480, ops: 5, latencyTicks: 3}}
+	tests := map[string]struct {
+		prev     volumeSample
+		interval time.Duration
+		iops     float64
+	}{
+		"first sample":      {volumeSample{}, 6 * time.Second, 5.0 / 6},
+		"sample before":     {prev, 2 * time.Second, 2},
+		"bdev recreated":    {volumeSample{tickRate: 1000, ticks: 4000, read: ioCounters{ops: 9}}, 6 * time.Second, 5.0 / 6},
+		"same sample again": {sample, 6 * time.Second, 5.0 / 6},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			stats := volumeStats("Malloc0", tt.prev, sample)
+			if stats.VolumeId.Value != "Malloc0" || stats.Read.Bytes != 20480 || stats.Read.Operations != 5 || stats.Read.LatencyUs != 3000 {
+				t.Errorf("unexpected counters %v", stats)
+			}
+			if d := stats.Interval.AsDuration(); d != tt.interval {
+				t.Errorf("expected an interval of %v, got %v", tt.interval, d)
+			}
+			if stats.Read.Iops != tt.iops || stats.Write.Iops != 0 {
+				t.Errorf("expected %v read IOPS, got %v", tt.iops, stats)
+			}
+		})
+	}
+}
+
+func TestStats_GetVolumeStats(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestNamespace(t, c)
+	ctx := context.Background()
+	spdk.mu.Lock()
+	b := spdk.bdevs["Malloc1"]
+	b.bytesRead, b.readOps = 8192, 2
+	spdk.mu.Unlock()
+
+	first, err := c.volume.GetVolumeStats(ctx, &bridge.GetVolumeStatsRequest{Id: &pc.ObjectKey{Value: "namespace-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.VolumeId.Value != "Malloc1" || first.Read.OperationsDelta != 2 || first.Interval.AsDuration() != time.Second {
+		t.Errorf("expected the first sample since SPDK started, got %v", first)
+	}
+
+	// another caller's sample does not move the interval of this one
+	if _, err := c.volume.GetVolumeStats(ctx, &bridge.GetVolumeStatsRequest{Id: &pc.ObjectKey{Value: "Malloc1"}}); err != nil {
+		t.Fatal(err)
+	}
+	spdk.mu.Lock()
+	b.bytesRead, b.readOps = 24576, 6
+	spdk.mu.Unlock()
+	next, err := c.volume.GetVolumeStats(ctx, &bridge.GetVolumeStatsRequest{Id: &pc.ObjectKey{Value: "Malloc1"}, Previous: first})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &bridge.IoStats{Bytes: 24576, Operations: 6, Iops: 2, Bandwidth: 8192, BytesDelta: 16384, OperationsDelta: 4}
+	if !proto.Equal(next.Read, want) || next.Interval.AsDuration() != 2*time.Second {
+		t.Errorf("expected %v over 2s, got %v", want, next)
+	}
+
+	other := proto.Clone(first).(*bridge.VolumeStats)
+	other.VolumeId.Value = "Malloc0"
+	_, err = c.volume.GetVolumeStats(ctx, &bridge.GetVolumeStatsRequest{Id: &pc.ObjectKey{Value: "Malloc1"}, Previous: other})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a sample of another volume, got %v", err)
+	}
+	_, err = c.volume.GetVolumeStats(ctx, &bridge.GetVolumeStatsRequest{Id: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"flag"
+	"sort"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var dbFile = flag.String("db_file", "/var/tmp/opi-spdk-bridge.db", "File the bridge keeps its objects in across restarts, empty to keep them in memory only")
+
+// objectStore persists registry objects so the bridge still knows them
+// after a restart. Objects are grouped by kind and keyed by their ID.
+type objectStore interface {
+	// load calls fn for every object saved under kind
+	load(kind string, fn func(id string, data []byte) error) error
+	// update runs fn in a write transaction that is committed only when
+	// fn succeeds; fn's error is returned as is
+	update(fn func(tx storeTx) error) error
+	close() error
+}
+
+// storeTx is the write side of an objectStore transaction
+type storeTx interface {
+	put(kind, id string, data []byte) error
+	remove(kind, id string) error
+}
+
+// newStore opens the store kept in path, or an in-memory store when path
+// is empty
+func newStore(path string) (objectStore, error) {
+	if path == "" {
+		return newMemoryStore(), nil
+	}
+	return newBoltStore(path)
+}
+
+// ////////////////////////////////////////////////////////
+
+// boltStore keeps objects in an embedded bbolt file with one bucket per kind
+type boltStore struct {
+	db *bolt.DB
+}
+
+func newBoltStore(path string) (*boltStore, error) {
+	// a second bridge on the same file fails instead of waiting forever
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) load(kind string, fn func(id string, data []byte) error) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(kind))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			return fn(string(k), v)
+		})
+	})
+}
+
+func (s *boltStore) update(fn func(tx storeTx) error) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return fn(boltTx{tx})
+	})
+}
+
+func (s *boltStore) close() error {
+	return s.db.Close()
+}
+
+type boltTx struct {
+	tx *bolt.Tx
+}
+
+func (t boltTx) put(kind, id string, data []byte) error {
+	b, err := t.tx.CreateBucketIfNotExists([]byte(kind))
+	if err != nil {
+		return err
+	}
+	return b.Put([]byte(id), data)
+}
+
+func (t boltTx) remove(kind, id string) error {
+	b := t.tx.Bucket([]byte(kind))
+	if b == nil {
+		return nil
+	}
+	return b.Delete([]byte(id))
+}
+
+// ////////////////////////////////////////////////////////
+
+// memoryStore keeps objects for the lifetime of the process only
+type memoryStore struct {
+	mu      sync.Mutex
+	objects map[string]map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{objects: map[string]map[string][]byte{}}
+}
+
+func (s *memoryStore) load(kind string, fn func(id string, data []byte) error) error {
+	s.mu.Lock()
+	objects := make(map[string][]byte, len(s.objects[kind]))
+	ids := make([]string, 0, len(s.objects[kind]))
+	for id, data := range s.objects[kind] {
+		objects[id] = data
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+	sort.Strings(ids)
+	for _, id := range ids {
+		if err := fn(id, objects[id]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) update(fn func(tx storeTx) error) error {
+	// like bbolt, allow a single writer at a time
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tx := &memoryTx{}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	for _, op := range tx.ops {
+		objects, ok := s.objects[op.kind]
+		if !ok {
+			objects = map[string][]byte{}
+			s.objects[op.kind] = objects
+		}
+		if op.data == nil {
+			delete(objects, op.id)
+		} else {
+			objects[op.id] = op.data
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) close() error {
+	return nil
+}
+
+// memoryTx collects writes until the transaction commits, a nil data
+// meaning removal
+type memoryTx struct {
+	ops []memoryOp
+}
+
+type memoryOp struct {
+	kind, id string
+	data     []byte
+}
+
+func (t *memoryTx) put(kind, id string, data []byte) error {
+	t.ops = append(t.ops, memoryOp{kind, id, append([]byte{}, data...)})
+	return nil
+}
+
+func (t *memoryTx) remove(kind, id string) error {
+	t.ops = append(t.ops, memoryOp{kind: kind, id: id})
+	return nil
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// openBridge serves a bridge whose objects are kept in the bbolt file at
+// path, returning a function that stops it and closes the file
+func openBridge(t *testing.T, path string) (*bridgeClients, func()) {
+	db, err := newBoltStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, err := newServer(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	closed := false
+	stop := func() {
+		if !closed {
+			closed = true
+			db.close()
+		}
+	}
+	t.Cleanup(stop)
+	return serveServer(t, srv), stop
+}
+
+func TestStore_Restart(t *testing.T) {
+	newFakeSpdk(t)
+	path := filepath.Join(t.TempDir(), "bridge.db")
+	ctx := context.Background()
+
+	c, stop := openBridge(t, path)
+	createTestNamespace(t, c)
+	if _, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Fatal(err)
+	}
+	stop()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, testCrypto().Key) {
+		t.Error("crypto key written to the store")
+	}
+
+	// a restarted bridge still maps the IDs to what SPDK has
+	c, _ = openBridge(t, path)
+	subsys, err := c.nvme.GetNVMeSubsystem(ctx, &pb.GetNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subsys.Spec.Nqn != testNqn {
+		t.Errorf("unexpected subsystem %v", subsys)
+	}
+	// without its key, which is never written to the store
+	if crypto, ok := c.server.registry.cryptos.load("Crypto42"); !ok || len(crypto.(*pb.Crypto).Key) != 0 {
+		t.Errorf("crypto not restored without its key: %v", crypto)
+	}
+	if _, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: testCrypto()}); err != nil {
+		t.Errorf("expected the retry to succeed, got %v", err)
+	}
+	other := testCrypto()
+	other.VolumeId.Value = "Malloc1"
+	if _, err := c.middleend.CreateCrypto(ctx, &pb.CreateCryptoRequest{Volume: other}); status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists, got %v", err)
+	}
+	if _, err := c.nvme.DeleteNVMeNamespace(ctx, &pb.DeleteNVMeNamespaceRequest{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStore_SpdkFailure(t *testing.T) {
+	spdk := newFakeSpdk(t)
+	path := filepath.Join(t.TempDir(), "bridge.db")
+	ctx := context.Background()
+
+	c, stop := openBridge(t, path)
+	spdk.setError("nvmf_create_subsystem", fakeEEXIST, "exists")
+	_, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: testSubsystem()})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+	spdk.clearError("nvmf_create_subsystem")
+	createTestSubsystem(t, c)
+	spdk.setError("nvmf_delete_subsystem", -16, "busy")
+	_, err = c.nvme.DeleteNVMeSubsystem(ctx, &pb.DeleteNVMeSubsystemRequest{SubsystemId: &pc.ObjectKey{Value: "subsystem-test"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	stop()
+
+	// the failed delete is not persisted either
+	c, _ = openBridge(t, path)
+	if _, ok := c.server.registry.subsystem("subsystem-test"); !ok {
+		t.Error("subsystem lost after a failed delete")
+	}
+}
+
+func TestStore_Transaction(t *testing.T) {
+	errApply := errors.New("apply failed")
+	stores := map[string]func(t *testing.T) objectStore{
+		"memory": func(t *testing.T) objectStore { return newMemoryStore() },
+		"bolt": func(t *testing.T) objectStore {
+			db, err := newBoltStore(filepath.Join(t.TempDir(), "bridge.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { db.close() })
+			return db
+		},
+	}
+	for name, open := range stores {
+		t.Run(name, func(t *testing.T) {
+			db := open(t)
+			err := db.update(func(tx storeTx) error {
+				if err := tx.put("kind", "a", []byte("a")); err != nil {
+					return err
+				}
+				return tx.put("kind", "b", []byte("b"))
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = db.update(func(tx storeTx) error {
+				if err := tx.remove("kind", "a"); err != nil {
+					return err
+				}
+				if err := tx.put("kind", "c", []byte("c")); err != nil {
+					return err
+				}
+				return errApply
+			})
+			if !errors.Is(err, errApply) {
+				t.Fatalf("expected the apply error, got %v", err)
+			}
+			var ids []string
+			err = db.load("kind", func(id string, data []byte) error {
+				if string(data) != id {
+					t.Errorf("unexpected data %q for %s", data, id)
+				}
+				ids = append(ids, id)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ids) != 2 || ids[0] != "a" || ids[1] != "b" {
+				t.Errorf("expected the failed transaction rolled back, got %v", ids)
+			}
+			if err := db.load("missing", func(string, []byte) error { return errApply }); err != nil {
+				t.Errorf("unexpected error for an empty kind: %v", err)
+			}
+		})
+	}
+}
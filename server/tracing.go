@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	otlpEndpoint = flag.String("otlp_endpoint", os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"), "URL of the OpenTelemetry collector traces are sent to over OTLP/HTTP, at <url>/v1/traces")
+	traceFile    = flag.String("trace_file", "", "File spans are appended to as JSON when there is no collector, - for stdout, none by default")
+)
+
+// tracerName names the spans the bridge itself starts
+const tracerName = "opi.storage.v1"
+
+// newTracerProvider exports traces to the collector at endpoint, or to
+// file without one. The provider does not sample without either.
+func newTracerProvider(endpoint, file string) (*sdktrace.TracerProvider, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch {
+	case endpoint != "":
+		exporter, err = otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+"/v1/traces"))
+	case file == "-":
+		exporter, err = stdouttrace.New()
+	case file != "":
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		spans, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			if cerr := f.Close(); cerr != nil {
+				log.Printf("error: %v", cerr)
+			}
+			return nil, err
+		}
+		exporter = fileExporter{spans, f}
+	default:
+		return sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample())), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "opi-spdk-bridge"))),
+	), nil
+}
+
+// shutdownTracing exports the spans tp still holds, giving up on a collector
+// that does not take them within a few seconds
+func shutdownTracing(tp *sdktrace.TracerProvider) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tp.Shutdown(ctx); err != nil {
+		log.Printf("error: %v", err)
+	}
+}
+
+// startCallSpan starts the span of the JSON RPC request id to SPDK
+func startCallSpan(ctx context.Context, method string, id int32) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "jsonrpc"),
+			attribute.String("rpc.jsonrpc.version", "2.0"),
+			attribute.String("rpc.method", method),
+			attribute.String("rpc.jsonrpc.request_id", strconv.Itoa(int(id))),
+		))
+}
+
+// endCallSpan records the SPDK error code of err, if SPDK returned one, and
+// the gRPC code the bridge maps it to
+func endCallSpan(span trace.Span, err error) {
+	if err != nil {
+		var spdkErr *SpdkError
+		if errors.As(err, &spdkErr) {
+			span.SetAttributes(attribute.Int("rpc.jsonrpc.error_code", spdkErr.Code))
+		}
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(status.Code(err))))
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+// fileExporter closes the file the spans are written to on shutdown
+type fileExporter struct {
+	sdktrace.SpanExporter
+	f *os.File
+}
+
+func (e fileExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := e.f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// useTracerProvider makes the bridges the test starts afterwards trace with
+// tp, until the test ends
+func useTracerProvider(t *testing.T, tp *sdktrace.TracerProvider) {
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+}
+
+// traceSpan is the part of the JSON stdouttrace writes per span the tests
+// look at
+type traceSpan struct {
+	Name        string
+	SpanContext struct{ TraceID, SpanID string }
+	Parent      struct{ TraceID, SpanID string }
+	Attributes  []struct {
+		Key   string
+		Value struct{ Value interface{} }
+	}
+	Status struct{ Code string }
+}
+
+// readTraces returns the spans in file by name
+func readTraces(t *testing.T, file string) map[string]traceSpan {
+	t.Helper()
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	spans := map[string]traceSpan{}
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var span traceSpan
+		if err := dec.Decode(&span); err != nil {
+			t.Fatal(err)
+		}
+		spans[span.Name] = span
+	}
+	return spans
+}
+
+// spanAttribute returns the value of the attribute key of span, nil without
+// one
+func spanAttribute(span traceSpan, key string) interface{} {
+	for _, a := range span.Attributes {
+		if a.Key == key {
+			return a.Value.Value
+		}
+	}
+	return nil
+}
+
+func TestTracing_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "traces.json")
+	tp, err := newTracerProvider("", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	useTracerProvider(t, tp)
+	spdk, c := startBridge(t)
+	ctx := context.Background()
+	createTestSubsystem(t, c)
+	spdk.setError("bdev_null_create", fakeInvalidParams, "Invalid parameters")
+	if _, err := c.null.NullDebugCreate(ctx, &pb.NullDebugCreateRequest{Device: &pb.NullDebug{Handle: &pc.ObjectKey{Value: "Null42"}}}); err == nil {
+		t.Fatal("expected the create to fail")
+	}
+	if err := tp.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := readTraces(t, file)
+	server, ok := spans["opi_api.storage.v1.FrontendNvmeService/CreateNVMeSubsystem"]
+	if !ok {
+		t.Fatalf("missing span of the gRPC request in %v", spans)
+	}
+	call, ok := spans["nvmf_create_subsystem"]
+	if !ok {
+		t.Fatalf("missing span of the SPDK call in %v", spans)
+	}
+	if call.Parent.TraceID != server.SpanContext.TraceID || call.Parent.SpanID != server.SpanContext.SpanID {
+		t.Errorf("expected the SPDK call %v in the gRPC request %v", call, server)
+	}
+	if v, ok := spanAttribute(call, "rpc.jsonrpc.request_id").(string); !ok || v == "" {
+		t.Errorf("missing request ID in %v", call)
+	}
+	if v := spanAttribute(call, "rpc.method"); v != "nvmf_create_subsystem" {
+		t.Errorf("missing method in %v", call)
+	}
+	if call.Status.Code != "Unset" || spanAttribute(call, "rpc.jsonrpc.error_code") != nil {
+		t.Errorf("unexpected error in %v", call)
+	}
+
+	failed, ok := spans["bdev_null_create"]
+	if !ok {
+		t.Fatalf("missing span of the failed SPDK call in %v", spans)
+	}
+	if failed.Status.Code != "Error" {
+		t.Errorf("expected an error status in %v", failed)
+	}
+	if v := spanAttribute(failed, "rpc.jsonrpc.error_code"); v != float64(-32602) {
+		t.Errorf("expected SPDK error code -32602 in %v", failed)
+	}
+	if v := spanAttribute(failed, "rpc.grpc.status_code"); v != float64(3) {
+		t.Errorf("expected InvalidArgument in %v", failed)
+	}
+}
+
+func TestTracing_Collector(t *testing.T) {
+	received := make(chan *coltracepb.ExportTraceServiceRequest, 10)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/x-protobuf" {
+			t.Errorf("unexpected request %s %s of %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		traces := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, traces); err != nil {
+			t.Error(err)
+		}
+		received <- traces
+	}))
+	defer collector.Close()
+	tp, err := newTracerProvider(collector.URL, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useTracerProvider(t, tp)
+	_, c := startBridge(t)
+	createTestSubsystem(t, c)
+	if err := tp.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	close(received)
+	names := map[string]bool{}
+	for traces := range received {
+		for _, rs := range traces.ResourceSpans {
+			if v := rs.Resource.GetAttributes(); len(v) == 0 {
+				t.Errorf("missing resource of %v", rs)
+			}
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					names[span.Name] = true
+				}
+			}
+		}
+	}
+	if !names["nvmf_create_subsystem"] || !names["opi_api.storage.v1.FrontendNvmeService/CreateNVMeSubsystem"] {
+		t.Errorf("unexpected spans %v", names)
+	}
+}
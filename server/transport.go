@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// transportParams converts spec to SPDK's parameters. SPDK counts the I/O
+// queue pairs only, the spec the admin queue pair too.
+func transportParams(spec *bridge.NVMfTransportSpec) (NvmfCreateTransportParams, error) {
+	trtype, ok := spdkTrtypes[spec.Trtype]
+	if !ok {
+		return NvmfCreateTransportParams{}, status.Errorf(codes.InvalidArgument, "unsupported transport type %v", spec.Trtype)
+	}
+	if spec.SockPriority != 0 && spec.Trtype != pb.NvmeTransportType_NVME_TRANSPORT_TCP {
+		return NvmfCreateTransportParams{}, status.Errorf(codes.InvalidArgument, "socket priority is for TCP only, not %v", spec.Trtype)
+	}
+	params := NvmfCreateTransportParams{
+		Trtype:           trtype,
+		IoUnitSize:       int(spec.IoUnitSize),
+		NumSharedBuffers: int(spec.NumSharedBuffers),
+		SockPriority:     int(spec.SockPriority),
+	}
+	if spec.MaxQpairsPerCtrlr > 1 {
+		params.MaxIoQpairsPerCtrlr = int(spec.MaxQpairsPerCtrlr) - 1
+	}
+	if spec.InCapsuleDataSize != nil {
+		size := int(*spec.InCapsuleDataSize)
+		params.InCapsuleDataSize = &size
+	}
+	return params, nil
+}
+
+// transportSpec converts a transport SPDK reports back to a spec
+func transportSpec(r *NvmfGetTransportsResult) *bridge.NVMfTransportSpec {
+	inCapsuleDataSize := int32(r.InCapsuleDataSize)
+	spec := &bridge.NVMfTransportSpec{
+		IoUnitSize:        int32(r.IoUnitSize),
+		MaxQpairsPerCtrlr: int32(r.MaxIoQpairsPerCtrlr) + 1,
+		InCapsuleDataSize: &inCapsuleDataSize,
+		NumSharedBuffers:  int32(r.NumSharedBuffers),
+		SockPriority:      int32(r.SockPriority),
+	}
+	for trtype, name := range spdkTrtypes {
+		if strings.EqualFold(name, r.Trtype) {
+			spec.Trtype = trtype
+		}
+	}
+	return spec
+}
+
+func (s *server) CreateNVMfTransport(ctx context.Context, in *bridge.CreateNVMfTransportRequest) (*bridge.NVMfTransport, error) {
+	log.Printf("CreateNVMfTransport: Received from client: %v", in)
+	params, err := transportParams(in.Transport.Spec)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	var result NvmfCreateTransportResult
+	existing, err := s.registry.transports.create(ctx, in.Transport.Spec.Id.Value, in.Transport, recreating(ctx), func() error {
+		// SPDK has one transport per type and fails with an internal error
+		// on another
+		_, err := getTransport(ctx, params.Trtype)
+		if err == nil {
+			return status.Errorf(codes.AlreadyExists, "%s transport already exists", params.Trtype)
+		}
+		if status.Code(err) != codes.NotFound {
+			return err
+		}
+		return call(ctx, "nvmf_create_transport", &params, &result)
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	if existing != nil {
+		log.Printf("CreateNVMfTransport: %s already exists", in.Transport.Spec.Id.Value)
+		return existing.(*bridge.NVMfTransport), nil
+	}
+	log.Printf("Received from SPDK: %v", result)
+	return in.Transport, nil
+}
+
+// ListNVMfTransport returns every transport SPDK has, including those
+// created behind the bridge's back, which have no ID
+func (s *server) ListNVMfTransport(ctx context.Context, in *bridge.ListNVMfTransportRequest) (*bridge.ListNVMfTransportResponse, error) {
+	log.Printf("ListNVMfTransport: Received from client: %v", in)
+	var result []NvmfGetTransportsResult
+	err := call(ctx, "nvmf_get_transports", nil, &result)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	known := s.registry.transports.values()
+	Blobarray := make([]*bridge.NVMfTransport, len(result))
+	for i := range result {
+		spec := transportSpec(&result[i])
+		for _, m := range known {
+			if transport := m.(*bridge.NVMfTransport); transport.Spec.Trtype == spec.Trtype {
+				spec.Id = transport.Spec.Id
+			}
+		}
+		Blobarray[i] = &bridge.NVMfTransport{Spec: spec}
+	}
+	return &bridge.ListNVMfTransportResponse{Transports: Blobarray}, nil
+}
+
+// GetNVMfTransport returns the parameters SPDK actually uses, defaults
+// filled in
+func (s *server) GetNVMfTransport(ctx context.Context, in *bridge.GetNVMfTransportRequest) (*bridge.NVMfTransport, error) {
+	log.Printf("GetNVMfTransport: Received from client: %v", in)
+	transport, ok := s.registry.transport(in.TransportId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find transport %s", in.TransportId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	result, err := getTransport(ctx, spdkTrtypes[transport.Spec.Trtype])
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	log.Printf("Received from SPDK: %v", result)
+	spec := transportSpec(result)
+	spec.Id = transport.Spec.Id
+	return &bridge.NVMfTransport{Spec: spec}, nil
+}
+
+// getTransport returns what SPDK has for the transport type trtype
+func getTransport(ctx context.Context, trtype string) (*NvmfGetTransportsResult, error) {
+	var result []NvmfGetTransportsResult
+	if err := call(ctx, "nvmf_get_transports", nil, &result); err != nil {
+		return nil, err
+	}
+	for i := range result {
+		if strings.EqualFold(result[i].Trtype, trtype) {
+			return &result[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "Could not find %s transport", trtype)
+}
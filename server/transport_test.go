@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// testTransport is the TCP transport of the docker-compose setup
+func testTransport() *bridge.NVMfTransport {
+	inCapsuleDataSize := int32(0)
+	return &bridge.NVMfTransport{Spec: &bridge.NVMfTransportSpec{
+		Id:                &pc.ObjectKey{Value: "transport-test"},
+		Trtype:            pb.NvmeTransportType_NVME_TRANSPORT_TCP,
+		IoUnitSize:        8192,
+		MaxQpairsPerCtrlr: 5,
+		InCapsuleDataSize: &inCapsuleDataSize,
+	}}
+}
+
+func createTestTransport(t *testing.T, c *bridgeClients) {
+	_, err := c.transport.CreateNVMfTransport(context.Background(), &bridge.CreateNVMfTransportRequest{Transport: testTransport()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransport_Create(t *testing.T) {
+	tests := map[string]struct {
+		change  func(t *bridge.NVMfTransport)
+		spdkErr int
+		// a TCP transport was created behind the bridge's back
+		outside bool
+		code    codes.Code
+	}{
+		"valid request":     {func(t *bridge.NVMfTransport) {}, 0, false, codes.OK},
+		"unknown transport": {func(t *bridge.NVMfTransport) { t.Spec.Trtype = pb.NvmeTransportType_NVME_TRANSPORT_CUSTOM }, 0, false, codes.InvalidArgument},
+		"socket priority": {func(t *bridge.NVMfTransport) {
+			t.Spec.Trtype = pb.NvmeTransportType_NVME_TRANSPORT_RDMA
+			t.Spec.SockPriority = 1
+		}, 0, false, codes.InvalidArgument},
+		"invalid parameters": {func(t *bridge.NVMfTransport) {}, fakeInvalidParams, false, codes.InvalidArgument},
+		"created outside":    {func(t *bridge.NVMfTransport) {}, 0, true, codes.AlreadyExists},
+		"vfio-user by default": {func(t *bridge.NVMfTransport) {
+			t.Spec = &bridge.NVMfTransportSpec{Id: t.Spec.Id, Trtype: pb.NvmeTransportType_NVME_TRANSPORT_PCIE}
+		}, 0, false, codes.OK},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			if tt.outside {
+				if err := call(context.Background(), "nvmf_create_transport", &NvmfCreateTransportParams{Trtype: "TCP"}, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.spdkErr != 0 {
+				spdk.setError("nvmf_create_transport", tt.spdkErr, "failed")
+			}
+			transport := testTransport()
+			tt.change(transport)
+			response, err := c.transport.CreateNVMfTransport(context.Background(), &bridge.CreateNVMfTransportRequest{Transport: transport})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if err == nil && !proto.Equal(response, transport) {
+				t.Errorf("expected %v, got %v", transport, response)
+			}
+			if _, ok := c.server.registry.transport("transport-test"); ok != (err == nil) {
+				t.Errorf("transport recorded %v on error %v", ok, err)
+			}
+		})
+	}
+}
+
+func TestTransport_CreateExisting(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestTransport(t, c)
+	ctx := context.Background()
+	response, err := c.transport.CreateNVMfTransport(ctx, &bridge.CreateNVMfTransportRequest{Transport: testTransport()})
+	if err != nil || !proto.Equal(response, testTransport()) {
+		t.Errorf("expected the same transport again, got %v, %v", response, err)
+	}
+	if n := spdk.called("nvmf_create_transport"); n != 1 {
+		t.Errorf("expected the transport created once, got %d calls", n)
+	}
+
+	other := testTransport()
+	other.Spec.IoUnitSize = 16384
+	_, err = c.transport.CreateNVMfTransport(ctx, &bridge.CreateNVMfTransportRequest{Transport: other})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists for another spec, got %v", err)
+	}
+}
+
+func TestTransport_Get(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestTransport(t, c)
+	ctx := context.Background()
+
+	spdk.mu.Lock()
+	tcp := *spdk.transports["TCP"]
+	spdk.mu.Unlock()
+	if tcp.IoUnitSize != 8192 || tcp.MaxIoQpairsPerCtrlr != 4 || tcp.InCapsuleDataSize != 0 {
+		t.Errorf("unexpected transport in SPDK %+v", tcp)
+	}
+
+	response, err := c.transport.GetNVMfTransport(ctx, &bridge.GetNVMfTransportRequest{TransportId: &pc.ObjectKey{Value: "transport-test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SPDK's default fills in what the spec left out
+	want := testTransport().Spec
+	want.NumSharedBuffers = 511
+	if !proto.Equal(response.Spec, want) {
+		t.Errorf("expected %v, got %v", want, response.Spec)
+	}
+
+	_, err = c.transport.GetNVMfTransport(ctx, &bridge.GetNVMfTransportRequest{TransportId: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for unknown transport, got %v", err)
+	}
+}
+
+func TestTransport_List(t *testing.T) {
+	_, c := startBridge(t)
+	createTestTransport(t, c)
+	ctx := context.Background()
+	// created behind the bridge's back
+	if err := call(ctx, "nvmf_create_transport", &NvmfCreateTransportParams{Trtype: "RDMA"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := c.transport.ListNVMfTransport(ctx, &bridge.ListNVMfTransportRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Transports) != 2 {
+		t.Fatalf("unexpected transports %v", response.Transports)
+	}
+	unmanaged := response.Transports[0].Spec
+	if unmanaged.Id != nil || unmanaged.Trtype != pb.NvmeTransportType_NVME_TRANSPORT_RDMA || unmanaged.IoUnitSize != 8192 {
+		t.Errorf("unexpected transport %v", unmanaged)
+	}
+	if managed := response.Transports[1].Spec; managed.GetId().GetValue() != "transport-test" {
+		t.Errorf("unexpected transport %v", managed)
+	}
+}
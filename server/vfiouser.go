@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var vfiouserDir = flag.String("vfiouser_dir", "/var/tmp/vfiouser", "Directory with one vfio-user socket directory per NVMe controller, for QEMU to connect to")
+
+// controllerDir is the directory SPDK creates the vfio-user socket cntrl of
+// the controller in. It is named after the PCIe function the controller
+// emulates, so the hypervisor finds it without asking the bridge.
+func controllerDir(spec *pb.NVMeControllerSpec) (string, error) {
+	name, err := endpointName(spec)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(*vfiouserDir, name), nil
+}
+
+// controllerHostNqn is the host NQN the hypervisor connects to the
+// controller's endpoint with, which namespaces are made visible to
+func controllerHostNqn(spec *pb.NVMeControllerSpec) (string, error) {
+	name, err := endpointName(spec)
+	if err != nil {
+		return "", err
+	}
+	return "nqn.2022-11.io.opiproject:vfio-user:" + name, nil
+}
+
+// endpointName names the PCIe function the controller emulates
+func endpointName(spec *pb.NVMeControllerSpec) (string, error) {
+	pcie := spec.GetPcieId()
+	if pcie == nil {
+		return "", status.Error(codes.InvalidArgument, "missing PCIe ID")
+	}
+	return fmt.Sprintf("port%d-pf%d-vf%d", pcie.PortId, pcie.PhysicalFunction, pcie.VirtualFunction), nil
+}
+
+// controllerAddress is the vfio-user listen address of the controller
+func controllerAddress(spec *pb.NVMeControllerSpec) (NvmfListenAddress, error) {
+	dir, err := controllerDir(spec)
+	if err != nil {
+		return NvmfListenAddress{}, err
+	}
+	return NvmfListenAddress{Trtype: "VFIOUSER", Traddr: dir, Trsvcid: "0"}, nil
+}
+
+// endpointClaims are the PCIe functions of the controllers being created,
+// which the registry only has once they are
+type endpointClaims struct {
+	mu       sync.Mutex
+	creating map[string]bool
+}
+
+// claimEndpoint reserves the PCIe function of the controller spec while it
+// is created, failing with AlreadyExists when another controller emulates it
+// or is being created on it. The claim has to be released once the
+// registry has the controller, or has given up on it.
+func (s *server) claimEndpoint(spec *pb.NVMeControllerSpec) (release func(), err error) {
+	name, err := endpointName(spec)
+	if err != nil {
+		return nil, err
+	}
+	s.endpoints.mu.Lock()
+	defer s.endpoints.mu.Unlock()
+	if s.endpoints.creating[name] {
+		return nil, status.Errorf(codes.AlreadyExists, "PCIe function %s is taken by a controller being created", name)
+	}
+	for _, m := range s.registry.controllers.values() {
+		controller := m.(*pb.NVMeController)
+		if controller.Spec.Id.Value == spec.Id.Value {
+			continue
+		}
+		if other, err := endpointName(controller.Spec); err == nil && other == name {
+			return nil, status.Errorf(codes.AlreadyExists, "PCIe function %s is taken by controller %s", name, controller.Spec.Id.Value)
+		}
+	}
+	s.endpoints.creating[name] = true
+	return func() {
+		s.endpoints.mu.Lock()
+		defer s.endpoints.mu.Unlock()
+		delete(s.endpoints.creating, name)
+	}, nil
+}
+
+// vfioUserTransportLock is held while checking for and creating the
+// vfio-user transport, which concurrent controllers would otherwise both
+// create
+var vfioUserTransportLock = make(chan struct{}, 1)
+
+// ensureVfioUserTransport creates SPDK's vfio-user transport with its
+// defaults unless there is one already, which CreateNVMfTransport may have
+// created with other parameters
+func ensureVfioUserTransport(ctx context.Context) error {
+	select {
+	case vfioUserTransportLock <- struct{}{}:
+		defer func() { <-vfioUserTransportLock }()
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	_, err := getTransport(ctx, "VFIOUSER")
+	if status.Code(err) != codes.NotFound {
+		return err
+	}
+	var result NvmfCreateTransportResult
+	return call(ctx, "nvmf_create_transport", &NvmfCreateTransportParams{Trtype: "VFIOUSER"}, &result)
+}
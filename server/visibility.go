@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"log"
+
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// UpdateNVMeNamespaceVisibility replaces the controllers and hosts that see
+// a namespace created for a controller
+func (s *server) UpdateNVMeNamespaceVisibility(ctx context.Context, in *bridge.UpdateNVMeNamespaceVisibilityRequest) (*bridge.NVMeNamespaceVisibility, error) {
+	log.Printf("UpdateNVMeNamespaceVisibility: Received from client: %v", in)
+	namespace, ok := s.registry.namespace(in.Visibility.NamespaceId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find namespace %s", in.Visibility.NamespaceId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	if namespace.Spec.ControllerId == nil {
+		err := status.Errorf(codes.FailedPrecondition, "namespace %s is visible to any host, create it for a controller to restrict it", in.Visibility.NamespaceId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	subsys, ok := s.registry.subsystem(namespace.Spec.SubsystemId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find subsystem %s", namespace.Spec.SubsystemId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	visibility := &bridge.NVMeNamespaceVisibility{
+		NamespaceId:   in.Visibility.NamespaceId,
+		ControllerIds: in.Visibility.ControllerIds,
+		HostNqns:      in.Visibility.HostNqns,
+	}
+	hosts, err := s.visibleHosts(visibility, namespace.Spec.SubsystemId.Value)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	old, err := s.visibleHosts(s.registry.visibility(namespace), namespace.Spec.SubsystemId.Value)
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	nsid := int(namespace.Spec.HostNsid)
+	tx := newSaga(ctx)
+	defer tx.rollback()
+	err = s.registry.visibilities.put(ctx, in.Visibility.NamespaceId.Value, visibility, func() error {
+		for _, host := range hosts {
+			if contains(old, host) {
+				continue
+			}
+			params := NvmfNsAddHostParams{Nqn: subsys.Spec.Nqn, Nsid: nsid, Host: host}
+			var result NvmfNsAddHostResult
+			err := tx.call("nvmf_ns_add_host", &params, &result,
+				undoCall("nvmf_ns_remove_host", &NvmfNsRemoveHostParams{Nqn: params.Nqn, Nsid: nsid, Host: host}))
+			if err != nil {
+				return err
+			}
+		}
+		for _, host := range old {
+			if contains(hosts, host) {
+				continue
+			}
+			params := NvmfNsRemoveHostParams{Nqn: subsys.Spec.Nqn, Nsid: nsid, Host: host}
+			var result NvmfNsRemoveHostResult
+			err := tx.call("nvmf_ns_remove_host", &params, &result,
+				undoCall("nvmf_ns_add_host", &NvmfNsAddHostParams{Nqn: params.Nqn, Nsid: nsid, Host: host}))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	tx.commit()
+	return visibility, nil
+}
+
+func (s *server) GetNVMeNamespaceVisibility(ctx context.Context, in *bridge.GetNVMeNamespaceVisibilityRequest) (*bridge.NVMeNamespaceVisibility, error) {
+	log.Printf("GetNVMeNamespaceVisibility: Received from client: %v", in)
+	namespace, ok := s.registry.namespace(in.NamespaceId.Value)
+	if !ok {
+		err := status.Errorf(codes.NotFound, "unable to find namespace %s", in.NamespaceId.Value)
+		log.Printf("error: %v", err)
+		return nil, err
+	}
+	return s.registry.visibility(namespace), nil
+}
+
+// visibleHosts resolves the controllers of v, which have to be controllers
+// of the subsystem, to their host NQNs and adds the other hosts of v
+func (s *server) visibleHosts(v *bridge.NVMeNamespaceVisibility, subsystemID string) ([]string, error) {
+	var hosts []string
+	for _, id := range v.ControllerIds {
+		controller, ok := s.registry.controller(id.GetValue())
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "unable to find controller %s", id.GetValue())
+		}
+		if controller.Spec.SubsystemId.GetValue() != subsystemID {
+			return nil, status.Errorf(codes.InvalidArgument, "controller %s is not a controller of subsystem %s", id.GetValue(), subsystemID)
+		}
+		host, err := controllerHostNqn(controller.Spec)
+		if err != nil {
+			return nil, err
+		}
+		if !contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	for _, host := range v.HostNqns {
+		if host == "" {
+			return nil, status.Error(codes.InvalidArgument, "missing host NQN")
+		}
+		if !contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+// namespacesVisibleTo returns the IDs of the namespaces the controller
+// sees because they were made visible to it
+func (s *server) namespacesVisibleTo(controllerID string) []string {
+	var ids []string
+	for _, m := range s.registry.namespaces.values() {
+		namespace := m.(*pb.NVMeNamespace)
+		for _, id := range s.registry.visibility(namespace).ControllerIds {
+			if id.GetValue() == controllerID {
+				ids = append(ids, namespace.Spec.Id.Value)
+			}
+		}
+	}
+	return ids
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
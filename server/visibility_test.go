@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (c) 2022 Dell Inc, or its subsidiaries.
+
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	pc "github.com/opiproject/opi-api/common/v1/gen/go"
+	pb "github.com/opiproject/opi-api/storage/v1alpha1/gen/go"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	bridge "opi.storage.v1/proto/gen/go"
+)
+
+// testControllerHostNqn is the host NQN of the test controller
+const testControllerHostNqn = "nqn.2022-11.io.opiproject:vfio-user:port0-pf1-vf0"
+
+// testMaskedNamespace is the test namespace created for the test controller
+func testMaskedNamespace() *pb.NVMeNamespace {
+	namespace := testNamespace()
+	namespace.Spec.ControllerId = &pc.ObjectKey{Value: "controller-test"}
+	return namespace
+}
+
+func createTestMaskedNamespace(t *testing.T, c *bridgeClients) {
+	createTestSubsystem(t, c)
+	createTestController(t, c)
+	_, err := c.nvme.CreateNVMeNamespace(context.Background(), &pb.CreateNVMeNamespaceRequest{Namespace: testMaskedNamespace()})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// namespaceHosts returns the hosts SPDK shows the test namespace to
+func namespaceHosts(t *testing.T, spdk *fakeSpdk) []string {
+	spdk.mu.Lock()
+	defer spdk.mu.Unlock()
+	ns, err := spdk.namespace(testNqn, 1)
+	if err != nil {
+		t.Fatalf("namespace missing from SPDK: %v", err)
+	}
+	if !ns.noAutoVisible {
+		t.Error("expected the namespace hidden from other hosts")
+	}
+	return append([]string{}, ns.hosts...)
+}
+
+func TestVisibility_CreateNamespace(t *testing.T) {
+	tests := map[string]struct {
+		change  func(n *pb.NVMeNamespace)
+		spdkErr int
+		code    codes.Code
+	}{
+		"valid request":        {func(n *pb.NVMeNamespace) {}, 0, codes.OK},
+		"unknown controller":   {func(n *pb.NVMeNamespace) { n.Spec.ControllerId.Value = "unknown" }, 0, codes.NotFound},
+		"other subsystem":      {func(n *pb.NVMeNamespace) { n.Spec.ControllerId.Value = "controller-other" }, 0, codes.InvalidArgument},
+		"host refused by SPDK": {func(n *pb.NVMeNamespace) {}, fakeInvalidParams, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			spdk, c := startBridge(t)
+			ctx := context.Background()
+			createTestSubsystem(t, c)
+			createTestController(t, c)
+			other := &pb.NVMeSubsystem{Spec: &pb.NVMeSubsystemSpec{
+				Id:  &pc.ObjectKey{Value: "subsystem-other"},
+				Nqn: "nqn.2022-09.io.spdk:opi-other",
+			}}
+			if _, err := c.nvme.CreateNVMeSubsystem(ctx, &pb.CreateNVMeSubsystemRequest{Subsystem: other}); err != nil {
+				t.Fatal(err)
+			}
+			controller := &pb.NVMeController{Spec: &pb.NVMeControllerSpec{
+				Id:          &pc.ObjectKey{Value: "controller-other"},
+				SubsystemId: other.Spec.Id,
+				PcieId:      &pb.PciEndpoint{PhysicalFunction: 2},
+			}}
+			if _, err := c.nvme.CreateNVMeController(ctx, &pb.CreateNVMeControllerRequest{Controller: controller}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.spdkErr != 0 {
+				spdk.setError("nvmf_ns_add_host", tt.spdkErr, "failed")
+			}
+			namespace := testMaskedNamespace()
+			tt.change(namespace)
+			_, err := c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: namespace})
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+			if _, ok := c.server.registry.namespace("namespace-test"); ok != (err == nil) {
+				t.Errorf("namespace recorded %v on error %v", ok, err)
+			}
+			if err != nil {
+				spdk.mu.Lock()
+				left := len(spdk.subsystems[testNqn].namespaces)
+				spdk.mu.Unlock()
+				if left != 0 {
+					t.Error("namespace left in SPDK")
+				}
+				return
+			}
+			if hosts := namespaceHosts(t, spdk); !reflect.DeepEqual(hosts, []string{testControllerHostNqn}) {
+				t.Errorf("unexpected hosts %v", hosts)
+			}
+		})
+	}
+}
+
+func TestVisibility_Update(t *testing.T) {
+	spdk, c := startBridge(t)
+	createTestMaskedNamespace(t, c)
+	ctx := context.Background()
+	id := &pc.ObjectKey{Value: "namespace-test"}
+
+	response, err := c.visibility.GetNVMeNamespaceVisibility(ctx, &bridge.GetNVMeNamespaceVisibilityRequest{NamespaceId: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &bridge.NVMeNamespaceVisibility{NamespaceId: id, ControllerIds: []*pc.ObjectKey{{Value: "controller-test"}}}
+	if !proto.Equal(response, want) {
+		t.Errorf("expected %v, got %v", want, response)
+	}
+
+	want.HostNqns = []string{testHostNqn}
+	response, err = c.visibility.UpdateNVMeNamespaceVisibility(ctx, &bridge.UpdateNVMeNamespaceVisibilityRequest{Visibility: want})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(response, want) {
+		t.Errorf("expected %v, got %v", want, response)
+	}
+	if hosts := namespaceHosts(t, spdk); !reflect.DeepEqual(hosts, []string{testControllerHostNqn, testHostNqn}) {
+		t.Errorf("unexpected hosts %v", hosts)
+	}
+
+	// the new host is taken away again when hiding from the controller fails
+	other := &bridge.NVMeNamespaceVisibility{NamespaceId: id, HostNqns: []string{"nqn.2022-11.io.opiproject:host"}}
+	spdk.failNext("nvmf_ns_remove_host", fakeInvalidParams, "failed")
+	if _, err := c.visibility.UpdateNVMeNamespaceVisibility(ctx, &bridge.UpdateNVMeNamespaceVisibilityRequest{Visibility: other}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", err)
+	}
+	if hosts := namespaceHosts(t, spdk); !reflect.DeepEqual(hosts, []string{testControllerHostNqn, testHostNqn}) {
+		t.Errorf("unexpected hosts %v", hosts)
+	}
+	if response, _ := c.visibility.GetNVMeNamespaceVisibility(ctx, &bridge.GetNVMeNamespaceVisibilityRequest{NamespaceId: id}); !proto.Equal(response, want) {
+		t.Errorf("expected %v kept, got %v", want, response)
+	}
+
+	// a controller the namespace is visible to stays until it is hidden
+	if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition, got %v", err)
+	}
+	if _, err := c.visibility.UpdateNVMeNamespaceVisibility(ctx, &bridge.UpdateNVMeNamespaceVisibilityRequest{Visibility: other}); err != nil {
+		t.Fatal(err)
+	}
+	if hosts := namespaceHosts(t, spdk); !reflect.DeepEqual(hosts, other.HostNqns) {
+		t.Errorf("unexpected hosts %v", hosts)
+	}
+	if _, err := c.nvme.DeleteNVMeController(ctx, &pb.DeleteNVMeControllerRequest{ControllerId: &pc.ObjectKey{Value: "controller-test"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.visibility.GetNVMeNamespaceVisibility(ctx, &bridge.GetNVMeNamespaceVisibilityRequest{NamespaceId: &pc.ObjectKey{Value: "unknown"}})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestVisibility_UpdateInvalid(t *testing.T) {
+	_, c := startBridge(t)
+	createTestMaskedNamespace(t, c)
+	ctx := context.Background()
+	unmasked := testNamespace()
+	unmasked.Spec.Id.Value = "namespace-unmasked"
+	unmasked.Spec.HostNsid = 2
+	if _, err := c.nvme.CreateNVMeNamespace(ctx, &pb.CreateNVMeNamespaceRequest{Namespace: unmasked}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		visibility *bridge.NVMeNamespaceVisibility
+		code       codes.Code
+	}{
+		"unknown namespace":  {&bridge.NVMeNamespaceVisibility{NamespaceId: &pc.ObjectKey{Value: "unknown"}}, codes.NotFound},
+		"visible to any":     {&bridge.NVMeNamespaceVisibility{NamespaceId: unmasked.Spec.Id}, codes.FailedPrecondition},
+		"unknown controller": {&bridge.NVMeNamespaceVisibility{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}, ControllerIds: []*pc.ObjectKey{{Value: "unknown"}}}, codes.NotFound},
+		"missing host NQN":   {&bridge.NVMeNamespaceVisibility{NamespaceId: &pc.ObjectKey{Value: "namespace-test"}, HostNqns: []string{""}}, codes.InvalidArgument},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.visibility.UpdateNVMeNamespaceVisibility(ctx, &bridge.UpdateNVMeNamespaceVisibilityRequest{Visibility: tt.visibility})
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	response, err := c.visibility.GetNVMeNamespaceVisibility(ctx, &bridge.GetNVMeNamespaceVisibilityRequest{NamespaceId: unmasked.Spec.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.AnyHost || len(response.ControllerIds) != 0 {
+		t.Errorf("unexpected visibility %v", response)
+	}
+	unmasked.Spec.ControllerId = &pc.ObjectKey{Value: "controller-test"}
+	if _, err := c.nvme.UpdateNVMeNamespace(ctx, &pb.UpdateNVMeNamespaceRequest{Namespace: unmasked}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", err)
+	}
+}
+
+func TestVisibility_Recreated(t *testing.T) {
+	_, c := startBridge(t)
+	createTestMaskedNamespace(t, c)
+	ctx := context.Background()
+	visibility := &bridge.NVMeNamespaceVisibility{
+		NamespaceId:   &pc.ObjectKey{Value: "namespace-test"},
+		ControllerIds: []*pc.ObjectKey{{Value: "controller-test"}},
+		HostNqns:      []string{testHostNqn},
+	}
+	if _, err := c.visibility.UpdateNVMeNamespaceVisibility(ctx, &bridge.UpdateNVMeNamespaceVisibilityRequest{Visibility: visibility}); err != nil {
+		t.Fatal(err)
+	}
+
+	// SPDK comes back empty
+	spdk := newFakeSpdk(t)
+	r, err := newReconciler(c.server, policyRecreate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report := r.reconcile(ctx); report.Error != "" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+	if hosts := namespaceHosts(t, spdk); !reflect.DeepEqual(hosts, []string{testControllerHostNqn, testHostNqn}) {
+		t.Errorf("unexpected hosts %v", hosts)
+	}
+}